@@ -0,0 +1,129 @@
+package v
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// aliases holds the rules of each alias, by name
+var aliases = struct {
+	sync.RWMutex
+	rules map[string]string
+}{rules: make(map[string]string)}
+
+// aliasParam matches the parameters of aliases: $1, $2...
+var aliasParam = regexp.MustCompile(`\$[1-9]`)
+
+// Alias names a composition of rules, so that tags may refer to it:
+//
+//	v.Alias("name_field", "required,maxchar:255,matches:printable_ascii")
+//
+//	Name string `v:"name_field"`
+//
+// Rules may take parameters, $1 to $9, given as the arguments of the alias,
+// separated by "|":
+//
+//	v.Alias("bounded", "required,between:$1..$2")
+//
+//	Age int `v:"bounded:21|120"`
+//
+// Aliases may refer to other aliases, but not to themselves. They are
+// expanded when a type is first validated: register them at init time.
+// Errors are returned for invalid names, unparsable rules and cycles.
+func Alias(name, rules string) error {
+	switch {
+	case name == "" || strings.ContainsAny(name, ":,()!|;'\\ \t"):
+		return fmt.Errorf("invalid alias name %q", name)
+	case isBuiltin(name):
+		return fmt.Errorf("alias %s would shadow a rule", name)
+	case isGroup(name):
+		return fmt.Errorf("alias %s would shadow a group", name)
+	}
+
+	aliases.Lock()
+	previous, existed := aliases.rules[name]
+	aliases.rules[name] = rules
+	aliases.Unlock()
+
+	// parse the alias, its parameters standing for any argument
+	src := name + ":" + strings.TrimSuffix(strings.Repeat("x|", 9), "|")
+	for _, rule := range parseRules(src) {
+		if rule.kind == invalidNode {
+			aliases.Lock()
+			if existed {
+				aliases.rules[name] = previous
+			} else {
+				delete(aliases.rules, name)
+			}
+			aliases.Unlock()
+			return fmt.Errorf("invalid alias %s: %v", name, rule.err)
+		}
+	}
+
+	// types compile again on their next validation
+	plans.Range(func(t, _ interface{}) bool {
+		plans.Delete(t)
+		return true
+	})
+	return nil
+}
+
+// getAlias returns the rules of the named alias
+func getAlias(name string) (rules string, ok bool) {
+	aliases.RLock()
+	defer aliases.RUnlock()
+	rules, ok = aliases.rules[name]
+	return
+}
+
+// expand parses the rules of alias, given args, while expanding the
+// aliases listed in stack, so as to detect cycles.
+func expand(alias, args, rules string, stack []string) (*node, error) {
+	for _, name := range stack {
+		if name == alias {
+			return nil, aliasError{fmt.Errorf("alias cycle: %s -> %s", strings.Join(stack, " -> "), alias)}
+		}
+	}
+
+	var params []string
+	if args != "" {
+		params = strings.Split(args, "|")
+	}
+	var missing error
+	src := aliasParam.ReplaceAllStringFunc(rules, func(param string) string {
+		i, _ := strconv.Atoi(param[1:])
+		if i > len(params) {
+			missing = aliasError{fmt.Errorf("alias %s expects at least %d arguments, got %d", alias, i, len(params))}
+			return ""
+		}
+		return escapeArgs(params[i-1])
+	})
+	if missing != nil {
+		return nil, missing
+	}
+
+	p := &parser{src: src, aliases: append(stack[:len(stack):len(stack)], alias)}
+	terms, err := p.rules()
+	if err == nil && !p.eof() {
+		err = fmt.Errorf("unexpected %q at offset %d", p.src[p.pos], p.pos)
+	}
+	var aerr aliasError
+	switch {
+	case errors.As(err, &aerr):
+		return nil, err
+	case err != nil:
+		return nil, aliasError{fmt.Errorf("in alias %s: %v", alias, err)}
+	case len(terms) == 0:
+		return nil, aliasError{errors.New("empty alias " + alias)}
+	}
+	return &node{kind: aliasNode, name: alias, args: args, children: terms}, nil
+}
+
+// aliasError reports an alias which cannot be expanded
+type aliasError struct {
+	error
+}
@@ -0,0 +1,88 @@
+package v
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ladydascalie/v/validators"
+)
+
+func TestAlias(t *testing.T) {
+	for name, rules := range map[string]string{
+		"test_name":    "required,maxchar:5,matches:alpha",
+		"test_bounded": "between:$1..$2",
+		"test_short":   "test_name,test_bounded:1|$1",
+		"test_maybe":   "omitempty,maxchar:2",
+	} {
+		if err := Alias(name, rules); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type user struct {
+		Name  *string `v:"test_name"`
+		Nick  string  `v:"test_short:3"`
+		Title string  `v:"test_maybe"`
+	}
+	nick, long := "Jo", "Johnny"
+	if err := Struct(user{Name: &nick, Nick: "Bob"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := Struct(user{Name: &long, Nick: "Jane", Title: "Dr."})
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %v", err)
+	}
+	verr := errs[0].(ErrorValidation)
+	if verr.Code() != validators.CodeMaxcharTooLong {
+		t.Errorf("Code() = %s, want %s", verr.Code(), validators.CodeMaxcharTooLong)
+	}
+	if want := "test_name (required, maxchar:5, matches:alpha): expected maximum 5 characters"; !strings.Contains(verr.Err.Error(), want) {
+		t.Errorf("expected the expansion in %q", verr.Err.Error())
+	}
+	if !strings.Contains(errs[1].Error(), "test_bounded:1|3 (between:1..3)") {
+		t.Errorf("expected the parameters in %q", errs[1].Error())
+	}
+
+	// required and omitempty apply through aliases
+	if err := Struct(user{Nick: "Bob"}); err == nil || err.(Errors)[0].(ErrorRequired).Field != "Name" {
+		t.Errorf("expected Name to be required, got %v", err)
+	}
+
+	if got, want := GroupRules("test_short:3"), []string{"required", "maxchar:5", "matches:alpha", "between:1..3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupRules() = %v, want %v", got, want)
+	}
+}
+
+func TestAlias_errors(t *testing.T) {
+	if err := Alias("test_a", "required,test_b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Alias("test_b", "test_a"); err == nil || !strings.Contains(err.Error(), "test_b -> test_a -> test_b") {
+		t.Errorf("expected a cycle to be reported, got %v", err)
+	}
+	if _, ok := getAlias("test_b"); ok {
+		t.Error("expected the cyclic alias not to be registered")
+	}
+	if err := Alias("test_c", "test_c"); err == nil {
+		t.Error("expected a cycle to be reported")
+	}
+
+	for _, name := range []string{"", "a:b", "a,b", "required", "omitempty"} {
+		if err := Alias(name, "required"); err == nil {
+			t.Errorf("expected an error for the name %q", name)
+		}
+	}
+	if err := Alias("test_d", "(required"); err == nil {
+		t.Error("expected an error for unparsable rules")
+	}
+
+	if err := Alias("test_e", "between:$1..$2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := validate("test_e:1", 1, nil); err == nil || !strings.Contains(err.Error(), "expects at least 2 arguments") {
+		t.Errorf("expected missing arguments to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+// Package catalog holds the translations of the validation messages.
+//
+// Messages are text/template templates, stored per locale and keyed by
+// the code of the failure they describe (see the Code constants of the
+// validators package). The FieldKey template lays out a field's name
+// next to its message.
+package catalog
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// FieldKey is the key of the template combining a field name with its message
+const FieldKey = "field"
+
+// Data is what templates are executed with
+type Data struct {
+	// Field is the name of the field, as shown to humans
+	Field string
+	// Params holds the rule arguments
+	Params map[string]interface{}
+	// Value is the offending value
+	Value interface{}
+	// Message is the message of the failure, which is
+	// already translated when rendering the FieldKey template.
+	Message string
+}
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// Default is the catalog shipped with v, which holds
+// French, German and Japanese translations.
+var Default = mustLoadDefault()
+
+func mustLoadDefault() *Catalog {
+	c := New("en")
+	if err := c.LoadFS(locales, "locales/*.json"); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Catalog holds message templates per locale.
+// It is safe for concurrent use.
+type Catalog struct {
+	rw        sync.RWMutex
+	fallback  string
+	templates map[string]map[string]*template.Template
+}
+
+// New returns an empty Catalog, which falls back
+// on the given locale when a translation is missing.
+func New(fallback string) *Catalog {
+	return &Catalog{
+		fallback:  fallback,
+		templates: make(map[string]map[string]*template.Template),
+	}
+}
+
+// Add parses text as the template for key in the given locale
+func (c *Catalog) Add(locale, key, text string) error {
+	tmpl, err := template.New(key).Funcs(funcs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return fmt.Errorf("catalog: %s: %v", locale, err)
+	}
+	locale = normalize(locale)
+
+	c.rw.Lock()
+	defer c.rw.Unlock()
+	if c.templates[locale] == nil {
+		c.templates[locale] = make(map[string]*template.Template)
+	}
+	c.templates[locale][key] = tmpl
+	return nil
+}
+
+// Load adds the templates of a JSON object, mapping keys onto templates
+func (c *Catalog) Load(locale string, data []byte) error {
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return fmt.Errorf("catalog: %s: %v", locale, err)
+	}
+	for key, text := range messages {
+		if err := c.Add(locale, key, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadFS loads every JSON file matching pattern, such as the ones
+// of an embed.FS. Each file is named after its locale, as in `fr.json`.
+func (c *Catalog) LoadFS(fsys fs.FS, pattern string) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		locale := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		if err := c.Load(locale, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render executes the template for key in locale. Missing translations
+// fall back on the locale's language (fr-CA falls back on fr), then on
+// the catalog's fallback locale. ok is false when none of them hold one.
+func (c *Catalog) Render(locale, key string, data Data) (message string, ok bool) {
+	for _, candidate := range c.candidates(locale) {
+		c.rw.RLock()
+		tmpl, found := c.templates[candidate][key]
+		c.rw.RUnlock()
+		if !found {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			continue
+		}
+		return buf.String(), true
+	}
+	return "", false
+}
+
+func (c *Catalog) candidates(locale string) []string {
+	locale = normalize(locale)
+	candidates := []string{locale}
+	if i := strings.Index(locale, "-"); i > 0 {
+		candidates = append(candidates, locale[:i])
+	}
+	return append(candidates, normalize(c.fallback))
+}
+
+// normalize lowercases locales and uses hyphens as separators,
+// so that fr_CA, fr-CA and fr-ca are all the same.
+func normalize(locale string) string {
+	return strings.ToLower(strings.Replace(locale, "_", "-", -1))
+}
+
+var funcs = template.FuncMap{
+	// num formats numbers for human reading, showing wildcard bounds as infinity
+	"num": func(value interface{}) string {
+		f, ok := value.(float64)
+		if !ok {
+			return fmt.Sprint(value)
+		}
+		switch {
+		case f >= math.MaxFloat64:
+			return "∞"
+		case f <= -math.MaxFloat64:
+			return "-∞"
+		default:
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+	},
+	// join joins lists of strings
+	"join": func(values interface{}, sep string) string {
+		if list, ok := values.([]string); ok {
+			return strings.Join(list, sep)
+		}
+		return fmt.Sprint(values)
+	},
+	// has reports whether a parameter was provided
+	"has": func(params map[string]interface{}, key string) bool {
+		_, ok := params[key]
+		return ok
+	},
+	// type returns the Go type of a value
+	"type": func(value interface{}) string {
+		return fmt.Sprintf("%T", value)
+	},
+}
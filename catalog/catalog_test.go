@@ -0,0 +1,81 @@
+package catalog
+
+import (
+	"math"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCatalog_Render(t *testing.T) {
+	c := New("en")
+	err := c.LoadFS(fstest.MapFS{
+		"en.json": {Data: []byte(`{"greeting": "hello {{.Field}}", "bye": "bye"}`)},
+		"fr.json": {Data: []byte(`{"greeting": "bonjour {{.Field}}"}`)},
+	}, "*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Add("fr_CA", "bye", "bye-bye"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		locale  string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{name: "exact locale", locale: "fr", key: "greeting", want: "bonjour Jane"},
+		{name: "region falls back on language", locale: "fr-BE", key: "greeting", want: "bonjour Jane"},
+		{name: "region", locale: "fr-ca", key: "bye", want: "bye-bye"},
+		{name: "missing translation falls back", locale: "fr", key: "bye", want: "bye"},
+		{name: "unknown locale falls back", locale: "ja", key: "greeting", want: "hello Jane"},
+		{name: "unknown key", locale: "fr", key: "nope", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := c.Render(tt.locale, tt.key, Data{Field: "Jane"})
+			if ok == tt.wantErr {
+				t.Fatalf("Render() ok = %v, wantErr %v", ok, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_Load(t *testing.T) {
+	c := New("en")
+	if err := c.Load("fr", []byte(`not json`)); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+	if err := c.Load("fr", []byte(`{"broken": "{{.Field"}`)); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+}
+
+func TestDefault(t *testing.T) {
+	data := Data{
+		Field:  "age",
+		Params: map[string]interface{}{"min": 21.0, "max": math.MaxFloat64},
+		Value:  16,
+	}
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"fr", "une valeur entre 21 et ∞ est attendue, reçu : 16"},
+		{"de", "ein Wert zwischen 21 und ∞ wird erwartet, erhalten: 16"},
+		{"ja", "21から∞の間の値を指定してください（16）"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			got, ok := Default.Render(tt.locale, "between.out_of_range", data)
+			if !ok || got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package convert
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Set parses values into dst, which must be settable. Slices receive
+// every value, any other type only the first one. Pointers are allocated
+// as needed, and types implementing encoding.TextUnmarshaler parse themselves.
+func Set(dst reflect.Value, values ...string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() != reflect.Uint8 && !isTextUnmarshaler(dst) {
+		slice := reflect.MakeSlice(dst.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := SetString(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	}
+	return SetString(dst, values[0])
+}
+
+// SetString parses s into dst, which must be settable.
+// time.Time values are expected in the RFC 3339 format,
+// and time.Duration values in the time.ParseDuration one.
+func SetString(dst reflect.Value, s string) error {
+	if dst.Kind() == reflect.Ptr {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return SetString(dst.Elem(), s)
+	}
+	if isTextUnmarshaler(dst) {
+		return dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	if dst.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		dst.SetInt(int64(d))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("%s cannot be set from a single string", dst.Type())
+		}
+		dst.SetBytes([]byte(s))
+	default:
+		return fmt.Errorf("%s cannot be set from a string", dst.Type())
+	}
+	return nil
+}
+
+func isTextUnmarshaler(v reflect.Value) bool {
+	return v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType)
+}
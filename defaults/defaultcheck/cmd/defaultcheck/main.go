@@ -0,0 +1,13 @@
+// Command defaultcheck reports invalid `default` struct tags.
+// See the defaultcheck package.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/ladydascalie/v/defaults/defaultcheck"
+)
+
+func main() {
+	singlechecker.Main(defaultcheck.Analyzer)
+}
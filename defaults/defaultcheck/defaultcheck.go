@@ -0,0 +1,138 @@
+// Package defaultcheck defines an analyzer reporting the `default` tags
+// which the defaults package would fail to parse at run time.
+//
+// Run it with go vet, once installed:
+//
+//	go install github.com/ladydascalie/v/defaults/defaultcheck/cmd/defaultcheck@latest
+//	go vet -vettool=$(which defaultcheck) ./...
+//
+// Fields of types implementing encoding.TextUnmarshaler, other than
+// time.Time, parse themselves and are not checked.
+package defaultcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/ladydascalie/v/convert"
+)
+
+const (
+	tagname      = "default"
+	separatortag = "defaultSeparator"
+)
+
+// Analyzer reports invalid `default` tags
+var Analyzer = &analysis.Analyzer{
+	Name:     "defaultcheck",
+	Doc:      "report `default` struct tags which cannot be parsed into their field",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// reflected maps the named types known to the defaults package
+// onto their reflect counterparts
+var reflected = map[string]reflect.Type{
+	"time.Duration": reflect.TypeOf(time.Duration(0)),
+	"time.Time":     reflect.TypeOf(time.Time{}),
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	in.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+			raw, ok := reflect.StructTag(tag).Lookup(tagname)
+			if !ok {
+				continue
+			}
+			rt, ok := reflectType(pass.TypesInfo.TypeOf(field.Type))
+			if !ok {
+				continue
+			}
+			if err := parse(rt, raw, reflect.StructTag(tag).Get(separatortag)); err != nil {
+				pass.Reportf(field.Tag.Pos(), "invalid default %q: %v", raw, err)
+			}
+		}
+	})
+	return nil, nil
+}
+
+// parse converts raw into a value of type t, as the defaults package does
+func parse(t reflect.Type, raw, separator string) error {
+	values := []string{raw}
+	if t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		if separator == "" {
+			separator = ","
+		}
+		values = strings.Split(raw, separator)
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+	}
+	return convert.Set(reflect.New(t).Elem(), values...)
+}
+
+// reflectType returns the reflect counterpart of t, if the defaults
+// package parses it by kind
+func reflectType(t types.Type) (reflect.Type, bool) {
+	switch t := t.(type) {
+	case *types.Named:
+		if obj := t.Obj(); obj.Pkg() != nil {
+			if rt, ok := reflected[obj.Pkg().Path()+"."+obj.Name()]; ok {
+				return rt, true
+			}
+		}
+		if types.NewMethodSet(types.NewPointer(t)).Lookup(nil, "UnmarshalText") != nil {
+			return nil, false
+		}
+		return reflectType(t.Underlying())
+	case *types.Pointer:
+		elem, ok := reflectType(t.Elem())
+		if !ok {
+			return nil, false
+		}
+		return reflect.PointerTo(elem), true
+	case *types.Slice:
+		elem, ok := reflectType(t.Elem())
+		if !ok {
+			return nil, false
+		}
+		return reflect.SliceOf(elem), true
+	case *types.Basic:
+		rt, ok := basics[t.Kind()]
+		return rt, ok
+	}
+	return nil, false
+}
+
+var basics = map[types.BasicKind]reflect.Type{
+	types.String:  reflect.TypeOf(""),
+	types.Bool:    reflect.TypeOf(false),
+	types.Int:     reflect.TypeOf(int(0)),
+	types.Int8:    reflect.TypeOf(int8(0)),
+	types.Int16:   reflect.TypeOf(int16(0)),
+	types.Int32:   reflect.TypeOf(int32(0)),
+	types.Int64:   reflect.TypeOf(int64(0)),
+	types.Uint:    reflect.TypeOf(uint(0)),
+	types.Uint8:   reflect.TypeOf(uint8(0)),
+	types.Uint16:  reflect.TypeOf(uint16(0)),
+	types.Uint32:  reflect.TypeOf(uint32(0)),
+	types.Uint64:  reflect.TypeOf(uint64(0)),
+	types.Float32: reflect.TypeOf(float32(0)),
+	types.Float64: reflect.TypeOf(float64(0)),
+}
@@ -0,0 +1,26 @@
+package a
+
+import (
+	"net"
+	"time"
+)
+
+type level int
+
+type config struct {
+	Host    string        `default:"localhost"`
+	Port    int           `default:"http"` // want `invalid default "http"`
+	Small   int8          `default:"300"`  // want `invalid default "300"`
+	Ratio   float64       `default:"0.5"`
+	Debug   bool          `default:"yes"`        // want `invalid default "yes"`
+	Timeout time.Duration `default:"5"`          // want `invalid default "5"`
+	Since   time.Time     `default:"2020-01-02"` // want `invalid default "2020-01-02"`
+	Retries *int          `default:"3"`
+	Ports   []int         `default:"80;443" defaultSeparator:";"`
+	Codes   []uint        `default:"1,-2"` // want `invalid default "1,-2"`
+	Level   level         `default:"2"`
+	IP      net.IP        `default:"anything"`
+	Nested  struct {
+		Limit int `default:"ten"` // want `invalid default "ten"`
+	}
+}
@@ -0,0 +1,190 @@
+// Package defaults fills the zero-valued fields of structs
+// with the values set in their `default` tag.
+//
+// The tags of a type are parsed once, the first time it is seen, and any
+// invalid default is reported then, whether the field it belongs to is
+// zero or not. Invalid defaults thus only surface at run time, once Set
+// or Check is given their type. Check a type at init time to catch them
+// before anything runs:
+//
+//	var _ = defaults.MustCheck(Config{})
+//
+// or run the analyzer of the defaultcheck package with go vet, to catch
+// them before the program is built.
+package defaults
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ladydascalie/v/convert"
+)
+
+const (
+	tagname      = "default"
+	separatortag = "defaultSeparator"
+)
+
+// plan is what the tags of a struct type compile to
+type plan struct {
+	fields []fieldPlan
+	err    error
+}
+
+type fieldPlan struct {
+	index int
+	// value is the parsed default, invalid if the field has none
+	value reflect.Value
+	// nested is set for struct fields, or pointers to structs
+	nested bool
+}
+
+// plans caches the plan of each type
+var plans sync.Map
+
+// Set fills the zero-valued fields of the struct ptr points to with their
+// `default` tag. Strings, booleans, numbers, durations, times (RFC 3339),
+// types implementing encoding.TextUnmarshaler, pointers to those, and
+// slices of those are supported. Slices are read from comma separated lists,
+// or as set by the `defaultSeparator` tag. Nested structs are walked.
+func Set(ptr interface{}) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("only pointers to structs may be given defaults")
+	}
+	return set(rv.Elem())
+}
+
+// Check parses the `default` tags of the type of value, which is
+// a struct or a pointer to one, reporting the first invalid one.
+func Check(value interface{}) error {
+	t := reflect.TypeOf(value)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("only structs may be checked, got %T", value)
+	}
+	return compile(t).err
+}
+
+// MustCheck is like Check, but panics on invalid defaults.
+// It returns true, so that it may be assigned to a package level variable.
+func MustCheck(value interface{}) bool {
+	if err := Check(value); err != nil {
+		panic(err)
+	}
+	return true
+}
+
+func set(rv reflect.Value) error {
+	p := compile(rv.Type())
+	if p.err != nil {
+		return p.err
+	}
+	for _, fp := range p.fields {
+		value := rv.Field(fp.index)
+		if fp.value.IsValid() && value.IsZero() {
+			value.Set(clone(fp.value))
+			continue
+		}
+		if !fp.nested {
+			continue
+		}
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+		if err := set(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compile returns the plan of struct type t, compiling it on first use
+func compile(t reflect.Type) *plan {
+	if p, ok := plans.Load(t); ok {
+		return p.(*plan)
+	}
+
+	p := &plan{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		fp := fieldPlan{index: i}
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			fp.nested = true
+			// pointers are compiled when walked, so that recursive types terminate
+			if field.Type.Kind() == reflect.Struct {
+				if nested := compile(ft); nested.err != nil {
+					p.err = nested.err
+					break
+				}
+			}
+		}
+
+		if raw, ok := field.Tag.Lookup(tagname); ok {
+			value, err := parse(field, raw)
+			if err != nil {
+				p.err = fmt.Errorf("invalid default for %s.%s: %v", t.Name(), field.Name, err)
+				break
+			}
+			fp.value = value
+		}
+
+		if fp.value.IsValid() || fp.nested {
+			p.fields = append(p.fields, fp)
+		}
+	}
+
+	actual, _ := plans.LoadOrStore(t, p)
+	return actual.(*plan)
+}
+
+// parse converts the raw default of field into a value of its type
+func parse(field reflect.StructField, raw string) (reflect.Value, error) {
+	value := reflect.New(field.Type).Elem()
+	values := []string{raw}
+	if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() != reflect.Uint8 {
+		separator := field.Tag.Get(separatortag)
+		if separator == "" {
+			separator = ","
+		}
+		values = strings.Split(raw, separator)
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+	}
+	if err := convert.Set(value, values...); err != nil {
+		return reflect.Value{}, err
+	}
+	return value, nil
+}
+
+// clone copies slices and pointers, so that
+// structs do not share the values of their defaults.
+func clone(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Slice:
+		return reflect.AppendSlice(reflect.MakeSlice(value.Type(), 0, value.Len()), value)
+	case reflect.Ptr:
+		ptr := reflect.New(value.Type().Elem())
+		ptr.Elem().Set(clone(value.Elem()))
+		return ptr
+	default:
+		return value
+	}
+}
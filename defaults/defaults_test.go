@@ -0,0 +1,101 @@
+package defaults
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+type server struct {
+	Host    string        `default:"localhost"`
+	Port    int           `default:"8080"`
+	Ratio   float64       `default:"0.5"`
+	Debug   bool          `default:"true"`
+	Timeout time.Duration `default:"5s"`
+	Since   time.Time     `default:"2020-01-02T03:04:05Z"`
+	Tags    []string      `default:"a, b"`
+	Ports   []int         `default:"80;443" defaultSeparator:";"`
+	Retries *int          `default:"3"`
+	IP      net.IP        `default:"127.0.0.1"`
+	Nothing string
+}
+
+type config struct {
+	Server server
+	Backup *server
+	Name   string `default:"app"`
+}
+
+func TestSet(t *testing.T) {
+	cfg := config{
+		Server: server{Port: 9090},
+		Backup: &server{Host: "backup"},
+	}
+	if err := Set(&cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	s := cfg.Server
+	if s.Host != "localhost" || s.Port != 9090 || s.Ratio != 0.5 || !s.Debug || s.Timeout != 5*time.Second {
+		t.Errorf("unexpected scalars: %+v", s)
+	}
+	if !s.Since.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected time: %v", s.Since)
+	}
+	if strings.Join(s.Tags, ",") != "a,b" || len(s.Ports) != 2 || s.Ports[1] != 443 {
+		t.Errorf("unexpected slices: %v %v", s.Tags, s.Ports)
+	}
+	if s.Retries == nil || *s.Retries != 3 || s.IP.String() != "127.0.0.1" || s.Nothing != "" {
+		t.Errorf("unexpected values: %v %v %q", s.Retries, s.IP, s.Nothing)
+	}
+	if cfg.Backup.Host != "backup" || cfg.Backup.Port != 8080 || cfg.Name != "app" {
+		t.Errorf("unexpected nested values: %+v %q", cfg.Backup, cfg.Name)
+	}
+
+	// defaults must not be shared between structs
+	cfg.Server.Tags[0] = "changed"
+	*cfg.Server.Retries = 10
+	var other server
+	if err := Set(&other); err != nil {
+		t.Fatal(err)
+	}
+	if other.Tags[0] != "a" || *other.Retries != 3 {
+		t.Errorf("defaults were shared: %v %v", other.Tags, *other.Retries)
+	}
+}
+
+type invalid struct {
+	Port int `default:"http"`
+}
+
+func TestCheck(t *testing.T) {
+	if err := Check(config{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Check(&invalid{}); err == nil {
+		t.Error("expected an error for an invalid default")
+	}
+	// invalid defaults are reported even when the field is set
+	if err := Set(&invalid{Port: 80}); err == nil {
+		t.Error("expected an error for an invalid default")
+	}
+	if err := Check(struct {
+		Nested invalid
+	}{}); err == nil {
+		t.Error("expected an error for an invalid nested default")
+	}
+	if err := Check(42); err == nil {
+		t.Error("expected an error for a non struct")
+	}
+	if err := Set(invalid{}); err == nil {
+		t.Error("expected an error for a non pointer")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustCheck to panic")
+		}
+	}()
+	MustCheck(invalid{})
+}
@@ -0,0 +1,278 @@
+// Package env loads configuration structs from environment variables,
+// then validates them with v.
+//
+//	type Config struct {
+//		Port int      `env:"PORT" default:"8080" v:"between:1..65535"`
+//		Hosts []string `env:"HOSTS" v:"required"`
+//		DB   struct {
+//			URL string `env:"URL" v:"required"`
+//		} `envPrefix:"DB_"`
+//	}
+//
+// Nested structs, or pointers to structs, are loaded from the variables named
+// after their envPrefix tag. Nil pointers are only allocated when one of their
+// variables is set.
+// Every missing or invalid variable is reported at once, by its name.
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/ladydascalie/v"
+	"github.com/ladydascalie/v/convert"
+)
+
+const (
+	envtag       = "env"
+	prefixtag    = "envPrefix"
+	separatortag = "envSeparator"
+	defaulttag   = "default"
+	tagname      = "v"
+)
+
+// Error reports a missing or invalid environment variable
+type Error struct {
+	// Var is the name of the environment variable
+	Var string
+	Err error
+}
+
+// Error satisfies the builtin Error interface
+func (e Error) Error() string {
+	type messager interface {
+		Message() string
+	}
+	if m, ok := e.Err.(messager); ok {
+		return fmt.Sprintf("env: %s: %s", e.Var, m.Message())
+	}
+	return fmt.Sprintf("env: %s: %v", e.Var, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Option configures Load
+type Option func(*loader)
+
+// Prefix is prepended to the names of all the variables
+func Prefix(prefix string) Option {
+	return func(l *loader) {
+		l.prefix = prefix
+	}
+}
+
+// Lookup sets where variables are read from, os.LookupEnv by default
+func Lookup(lookup func(name string) (string, bool)) Option {
+	return func(l *loader) {
+		l.lookup = lookup
+	}
+}
+
+// Validator sets the Validator the loaded struct is validated with
+func Validator(validator *v.Validator) Option {
+	return func(l *loader) {
+		l.validator = validator
+	}
+}
+
+type loader struct {
+	prefix    string
+	lookup    func(string) (string, bool)
+	validator *v.Validator
+
+	// vars maps the Go path of each field onto its variable
+	vars map[string]string
+	// failed holds the Go paths of the fields already reported
+	failed map[string]bool
+	// loading holds the struct types being loaded, to stop on recursive types
+	loading map[reflect.Type]bool
+	errs    v.Errors
+}
+
+// Load populates the struct ptr points to from the environment, then
+// validates it. Fields are read from the variable named in their `env`
+// tag, falling back on their `default` tag when it is unset. Slices are
+// read from comma separated lists, or as set by the `envSeparator` tag.
+// Nested structs are walked, prepending their `envPrefix` tag to the
+// names of their fields' variables. Fields tagged `v:"required"` must be set.
+//
+// Failures are reported as a v.Errors holding an Error per variable.
+func Load(ptr interface{}, opts ...Option) error {
+	l := &loader{
+		lookup:    os.LookupEnv,
+		validator: v.New(),
+		vars:      make(map[string]string),
+		failed:    make(map[string]bool),
+		loading:   make(map[reflect.Type]bool),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("env: only pointers to structs may be loaded")
+	}
+	l.load(rv.Elem(), l.prefix, "")
+
+	err := l.validator.Struct(ptr)
+	var verrs v.Errors
+	if errors.As(err, &verrs) {
+		for _, err := range verrs {
+			l.report(err)
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if len(l.errs) == 0 {
+		return nil
+	}
+	return l.errs
+}
+
+// load populates the fields of rv, and reports whether any of their
+// variables is set
+func (l *loader) load(rv reflect.Value, prefix, path string) bool {
+	t := rv.Type()
+	l.loading[t] = true
+	defer delete(l.loading, t)
+
+	set := false
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := rv.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		fieldPath := path
+		if !field.Anonymous {
+			fieldPath = join(path, field.Name)
+		}
+
+		name, tagged := field.Tag.Lookup(envtag)
+		if !tagged && field.Type.Kind() == reflect.Struct {
+			set = l.load(value, prefix+field.Tag.Get(prefixtag), fieldPath) || set
+			continue
+		}
+		if !tagged && field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+			set = l.loadPtr(value, prefix+field.Tag.Get(prefixtag), fieldPath) || set
+			continue
+		}
+		if !tagged || name == "" || name == "-" {
+			continue
+		}
+		name = prefix + name
+		l.vars[fieldPath] = name
+
+		raw, ok := l.lookup(name)
+		set = set || ok
+		if !ok {
+			raw, ok = field.Tag.Lookup(defaulttag)
+		}
+		if !ok {
+			if isRequired(field) {
+				l.failed[fieldPath] = true
+				l.errs = append(l.errs, Error{Var: name, Err: v.ErrorRequired{
+					Field:      field.Name,
+					Path:       fieldPath,
+					StructPath: fieldPath,
+				}})
+			}
+			continue
+		}
+
+		values := []string{raw}
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+			values = split(raw, field.Tag.Get(separatortag))
+		}
+		if err := convert.Set(value, values...); err != nil {
+			l.failed[fieldPath] = true
+			l.errs = append(l.errs, Error{Var: name, Err: fmt.Errorf("invalid value %q: %v", raw, err)})
+		}
+	}
+	return set
+}
+
+// loadPtr loads the struct pointed to by rv. A nil pointer is only allocated
+// when one of its variables is set, and left nil when its type is already
+// being loaded, as in `type Node struct{ Next *Node }`.
+func (l *loader) loadPtr(rv reflect.Value, prefix, path string) bool {
+	if l.loading[rv.Type().Elem()] {
+		return false
+	}
+	if !rv.IsNil() {
+		return l.load(rv.Elem(), prefix, path)
+	}
+
+	elem := reflect.New(rv.Type().Elem())
+	errs := len(l.errs)
+	if !l.load(elem.Elem(), prefix, path) {
+		// the fields of an unset section are neither reported nor validated
+		l.errs = l.errs[:errs]
+		for p := range l.failed {
+			if strings.HasPrefix(p, path+".") {
+				delete(l.failed, p)
+			}
+		}
+		return false
+	}
+	rv.Set(elem)
+	return true
+}
+
+// report records a validation error, under the name of its variable
+func (l *loader) report(err error) {
+	var path string
+	switch e := err.(type) {
+	case v.ErrorValidation:
+		path = e.StructPath
+	case v.ErrorRequired:
+		path = e.StructPath
+	}
+	if l.failed[path] {
+		return
+	}
+	name, ok := l.vars[path]
+	if !ok {
+		name = path
+	}
+	l.errs = append(l.errs, Error{Var: name, Err: err})
+}
+
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range v.GroupRules(field.Tag.Get(tagname)) {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+func split(raw, separator string) []string {
+	if separator == "" {
+		separator = ","
+	}
+	if raw == "" {
+		return nil
+	}
+	values := strings.Split(raw, separator)
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
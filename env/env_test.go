@@ -0,0 +1,154 @@
+package env
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ladydascalie/v"
+	"github.com/ladydascalie/v/validators"
+)
+
+type database struct {
+	URL   string `env:"URL" v:"required"`
+	Conns int    `env:"CONNS" default:"10" v:"between:1..100"`
+}
+
+type config struct {
+	Port    int           `env:"PORT" default:"8080" v:"between:1..65535"`
+	Hosts   []string      `env:"HOSTS" v:"required"`
+	Ports   []int         `env:"PORTS" envSeparator:";"`
+	Timeout time.Duration `env:"TIMEOUT" default:"5s"`
+	Mode    string        `env:"MODE" v:"in:dev|prod"`
+	DB      database      `envPrefix:"DB_"`
+	Ignored string
+}
+
+func lookup(vars map[string]string) Option {
+	return Lookup(func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	})
+}
+
+func TestLoad(t *testing.T) {
+	var cfg config
+	err := Load(&cfg, Prefix("APP_"), lookup(map[string]string{
+		"APP_HOSTS":  "a.example.com, b.example.com",
+		"APP_PORTS":  "80;443",
+		"APP_MODE":   "prod",
+		"APP_DB_URL": "postgres://localhost",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Port != 8080 || cfg.Timeout != 5*time.Second || cfg.Mode != "prod" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if strings.Join(cfg.Hosts, " ") != "a.example.com b.example.com" || len(cfg.Ports) != 2 || cfg.Ports[1] != 443 {
+		t.Errorf("unexpected lists: %v %v", cfg.Hosts, cfg.Ports)
+	}
+	if cfg.DB.URL != "postgres://localhost" || cfg.DB.Conns != 10 {
+		t.Errorf("unexpected nested config: %+v", cfg.DB)
+	}
+}
+
+func TestLoad_errors(t *testing.T) {
+	var cfg config
+	err := Load(&cfg, lookup(map[string]string{
+		"PORT":     "http",
+		"MODE":     "staging",
+		"DB_CONNS": "1000",
+	}))
+
+	var errs v.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected v.Errors, got %v", err)
+	}
+	got := make(map[string]error)
+	for _, err := range errs {
+		e := err.(Error)
+		got[e.Var] = e
+	}
+
+	for _, name := range []string{"PORT", "HOSTS", "MODE", "DB_URL", "DB_CONNS"} {
+		if _, ok := got[name]; !ok {
+			t.Errorf("expected %s to be reported", name)
+		}
+	}
+	if len(got) != 5 {
+		t.Errorf("expected 5 errors, got %v", err)
+	}
+
+	if want := "env: MODE: accepted values are: [dev, prod], but got: staging"; got["MODE"].Error() != want {
+		t.Errorf("got %q, want %q", got["MODE"], want)
+	}
+	var verr validators.Error
+	if !errors.As(got["DB_CONNS"], &verr) || verr.Code != validators.CodeBetweenOutOfRange {
+		t.Errorf("expected the validation error to be kept, got %v", got["DB_CONNS"])
+	}
+	var rerr v.ErrorRequired
+	if !errors.As(got["HOSTS"], &rerr) {
+		t.Errorf("expected HOSTS to be reported as missing, got %v", got["HOSTS"])
+	}
+}
+
+func TestLoad_pointers(t *testing.T) {
+	var cfg struct {
+		DB    *database `envPrefix:"DB_"`
+		Cache *database `envPrefix:"CACHE_"`
+	}
+	err := Load(&cfg, lookup(map[string]string{
+		"DB_URL":      "postgres://localhost",
+		"CACHE_CONNS": "1000",
+	}))
+	if cfg.DB == nil || cfg.DB.URL != "postgres://localhost" || cfg.DB.Conns != 10 {
+		t.Errorf("unexpected nested config: %+v", cfg.DB)
+	}
+
+	var errs v.Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", err)
+	}
+	for i, name := range []string{"CACHE_URL", "CACHE_CONNS"} {
+		if e := errs[i].(Error); e.Var != name {
+			t.Errorf("expected %s to be reported, got %v", name, e)
+		}
+	}
+}
+
+func TestLoad_unsetPointers(t *testing.T) {
+	var cfg struct {
+		DB *database `envPrefix:"DB_"`
+	}
+	if err := Load(&cfg, lookup(nil)); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DB != nil {
+		t.Errorf("expected an unset section to be left nil, got %+v", cfg.DB)
+	}
+}
+
+type node struct {
+	Name string `env:"NAME"`
+	Next *node  `envPrefix:"NEXT_"`
+}
+
+func TestLoad_recursive(t *testing.T) {
+	var cfg struct {
+		Head *node `envPrefix:"HEAD_"`
+	}
+	if err := Load(&cfg, lookup(map[string]string{"HEAD_NAME": "a"})); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Head == nil || cfg.Head.Name != "a" || cfg.Head.Next != nil {
+		t.Errorf("unexpected list: %+v", cfg.Head)
+	}
+}
+
+func TestLoad_notAPointer(t *testing.T) {
+	if err := Load(config{}); err == nil {
+		t.Error("expected an error")
+	}
+}
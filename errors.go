@@ -1,37 +1,117 @@
 package v
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/ladydascalie/v/catalog"
+	"github.com/ladydascalie/v/validators"
 )
 
 // ErrorRequired is the type of error thrown when a required field is missing
 type ErrorRequired struct {
 	Field    string
 	JSONName string
+	// Path locates the field from the validated structure,
+	// as a dotted list of json names, or Go names when missing
+	Path string
+	// StructPath locates the field as a dotted list of Go names
+	StructPath string
+	// Label is the human-facing name of the field, if it has one
+	Label string
+	// Locale is the locale the message is rendered in, English if empty
+	Locale string
+
+	messages *catalog.Catalog
 }
 
 // Error satisfies the builtin Error interface
 func (e ErrorRequired) Error() string {
-	if e.JSONName != "" {
-		return fmt.Sprintf("[validation] %s: required, please provide a value", e.JSONName)
+	name := displayName(e.messages, e.Locale, e.Label, e.JSONName, e.Field)
+	if e.Locale != "" {
+		return localize(e.messages, e.Locale, name, errRequired)
 	}
-	return fmt.Sprintf("[validation] %s: required, please provide a value", e.Field)
+	return fmt.Sprintf("[validation] %s: required, please provide a value", name)
+}
+
+// Message returns the message of the failure, without the field name
+func (e ErrorRequired) Message() string {
+	name := displayName(e.messages, e.Locale, e.Label, e.JSONName, e.Field)
+	return message(e.messages, e.Locale, name, errRequired)
+}
+
+var errRequired = validators.NewError(validators.CodeRequired, nil, nil, "required, please provide a value")
+
+// Code returns the code identifying the failure
+func (e ErrorRequired) Code() string {
+	return validators.CodeRequired
 }
 
 // ErrorValidation is the type of error thrown on a validation error
 type ErrorValidation struct {
 	Name     string
 	JSONName string
-	Err      error
+	// Path locates the field from the validated structure,
+	// as a dotted list of json names, or Go names when missing
+	Path string
+	// StructPath locates the field as a dotted list of Go names
+	StructPath string
+	Err        error
+	// Label is the human-facing name of the field, if it has one
+	Label string
+	// Locale is the locale the message is rendered in, English if empty
+	Locale string
+
+	messages *catalog.Catalog
 }
 
 // Error satisfies the builtin Error interface
 func (e ErrorValidation) Error() string {
-	if e.JSONName != "" {
-		return fmt.Sprintf("[validation] %s: %v", e.JSONName, e.Err)
+	name := displayName(e.messages, e.Locale, e.Label, e.JSONName, e.Name)
+	if e.Locale != "" {
+		return localize(e.messages, e.Locale, name, e.Err)
 	}
-	return fmt.Sprintf("[validation] %s: %v", e.Name, e.Err)
+	return fmt.Sprintf("[validation] %s: %v", name, e.Err)
+}
+
+// Message returns the message of the failure, without the field name
+func (e ErrorValidation) Message() string {
+	name := displayName(e.messages, e.Locale, e.Label, e.JSONName, e.Name)
+	return message(e.messages, e.Locale, name, e.Err)
+}
+
+// Unwrap returns the underlying validator error
+func (e ErrorValidation) Unwrap() error {
+	return e.Err
+}
+
+// Code returns the code identifying the failure, or an empty
+// string if the underlying error did not come from a built-in validator.
+func (e ErrorValidation) Code() string {
+	var verr validators.Error
+	if errors.As(e.Err, &verr) {
+		return verr.Code
+	}
+	return ""
+}
+
+// Errors is the list of errors found while validating a structure.
+// Use errors.As to retrieve any of them by type.
+type Errors []error
+
+// Error satisfies the builtin Error interface
+func (e Errors) Error() string {
+	var messages []string
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, " | ")
+}
+
+// Unwrap returns the collected errors
+func (e Errors) Unwrap() []error {
+	return e
 }
 
 type validationErorrs []error
@@ -41,9 +121,5 @@ func (v validationErorrs) Error() error {
 	if len(v) == 0 {
 		return nil
 	}
-	var messages []string
-	for _, err := range v {
-		messages = append(messages, err.Error())
-	}
-	return fmt.Errorf("%s", strings.Join(messages, " | "))
+	return Errors(v)
 }
@@ -1 +1,14 @@
 module github.com/ladydascalie/v
+
+go 1.22.0
+
+require (
+	golang.org/x/net v0.34.0
+	golang.org/x/text v0.21.0
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.10.0 // indirect
+)
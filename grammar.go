@@ -0,0 +1,437 @@
+package v
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ladydascalie/v/validators"
+)
+
+// The grammar of the rules of a tag, or of a section of it:
+//
+//	rules := term { "," term }
+//	term  := unary { "||" unary }
+//	unary := "!" unary | "(" rules ")" | rule
+//	rule  := name [ ":" args ]
+//
+// Commas bind the loosest, so that `required,matches:uuid4 || empty_string`
+// requires a value which is either a uuid4 or empty. Arguments run until the
+// next unescaped ",", "||" or ")", which may be escaped with a backslash or
+// written between single quotes: `in:'a,b'|c`. Backslashes only escape
+// ",", ")", "|", "'", ";", " " and themselves, and are kept before any
+// other character, so that `regex:^\d+$` needs no quotes. Quotes only open
+// at the start of an argument, after ":" or "|", so that `in:don't|x`
+// keeps its apostrophe. Arguments holding ",", ")" or "||", as regular
+// expressions such as `regex:'^\d{1,3}$'` or `regex:'^(a|b)$'` may, must
+// be quoted: unquoted, they are cut short, and the tag is reported as invalid
+// when its struct is first validated.
+// A single "|" is not an operator, and keeps separating the arguments of in.
+
+type nodeKind int
+
+const (
+	ruleNode nodeKind = iota
+	andNode
+	orNode
+	notNode
+	// aliasNode holds the rules an alias expands to
+	aliasNode
+	// invalidNode holds a tag which could not be parsed
+	invalidNode
+)
+
+// node is a parsed rule, or a composition of rules
+type node struct {
+	kind     nodeKind
+	name     string
+	args     string
+	children []*node
+	err      error
+}
+
+// is reports whether n is the rule name, without arguments
+func (n *node) is(name string) bool {
+	return n.kind == ruleNode && n.name == name && n.args == ""
+}
+
+// has reports whether n is the rule name, or an alias including it
+func (n *node) has(name string) bool {
+	if n.kind != aliasNode {
+		return n.is(name)
+	}
+	for _, child := range n.children {
+		if child.has(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns n as written in a tag. Compositions are wrapped in
+// parentheses, or start with "!", so that they stand out from rules.
+func (n *node) String() string {
+	switch n.kind {
+	case ruleNode, aliasNode:
+		if n.args == "" {
+			return n.name
+		}
+		return n.name + ":" + escapeArgs(n.args)
+	case notNode:
+		return "!" + n.children[0].String()
+	case invalidNode:
+		return n.args
+	}
+	separator := ", "
+	if n.kind == orNode {
+		separator = " || "
+	}
+	parts := make([]string, len(n.children))
+	for i, child := range n.children {
+		parts[i] = child.String()
+	}
+	return "(" + strings.Join(parts, separator) + ")"
+}
+
+// expansion returns the rules n expands to, if an alias
+func (n *node) expansion() string {
+	parts := make([]string, len(n.children))
+	for i, child := range n.children {
+		parts[i] = child.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// terms returns n as returned by GroupRules: aliases expanded, and rules
+// with their arguments unescaped, so that they may be split on their first colon.
+func (n *node) terms() []string {
+	switch {
+	case n.kind == aliasNode:
+		var terms []string
+		for _, child := range n.children {
+			terms = append(terms, child.terms()...)
+		}
+		return terms
+	case n.kind == ruleNode && n.args != "":
+		return []string{n.name + ":" + n.args}
+	default:
+		return []string{n.String()}
+	}
+}
+
+// run validates value against n, rules relative to the current time reading now
+func (n *node) run(value, structure interface{}, now validators.Clock) error {
+	switch n.kind {
+	case ruleNode:
+		return runRule(n.name, n.args, value, structure, now)
+	case andNode:
+		for _, child := range n.children {
+			if err := child.run(value, structure, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	case orNode:
+		alternatives := make([]string, len(n.children))
+		messages := make([]string, len(n.children))
+		for i, child := range n.children {
+			err := child.run(value, structure, now)
+			if err == nil {
+				return nil
+			}
+			alternatives[i] = child.String()
+			messages[i] = err.Error()
+		}
+		params := validators.Params{"alternatives": alternatives, "errors": messages}
+		parts := make([]string, len(alternatives))
+		for i := range alternatives {
+			parts[i] = alternatives[i] + ": " + messages[i]
+		}
+		return validators.NewError(validators.CodeOrNoMatch, value, params, "none of the alternatives passed: %s", strings.Join(parts, "; "))
+	case aliasNode:
+		for _, child := range n.children {
+			if child.is(omitempty) {
+				continue
+			}
+			err := child.run(value, structure, now)
+			if err == nil {
+				continue
+			}
+			// show what the alias expands to
+			var verr validators.Error
+			if errors.As(err, &verr) {
+				return validators.NewError(verr.Code, verr.Value, verr.Params, "%s (%s): %v", n, n.expansion(), err)
+			}
+			return fmt.Errorf("%s (%s): %w", n, n.expansion(), err)
+		}
+		return nil
+	case notNode:
+		err := n.children[0].run(value, structure, now)
+		if err == nil {
+			rule := n.children[0].String()
+			return validators.NewError(validators.CodeNotMatched, value, validators.Params{"rule": rule}, "expected a value not satisfying %s", rule)
+		}
+		// misused and missing rules are reported, rather than negated
+		var verr validators.Error
+		if errors.As(err, &verr) && isMisuse(verr.Code) || !n.children[0].exists() {
+			return err
+		}
+		return nil
+	default:
+		return fmt.Errorf("v cannot parse struct tag <%v> please refer to the format rules: %v", n.args, n.err)
+	}
+}
+
+// exists reports whether n is not a rule, or one that may be run: a built-in
+// rule, or a custom func registered in validators.CustomFuncMap
+func (n *node) exists() bool {
+	if n.kind != ruleNode {
+		return true
+	}
+	if n.name == "func" {
+		_, ok := validators.CustomFuncMap.Get(n.args)
+		return ok
+	}
+	_, ok := validators.FuncMap[n.name]
+	return ok
+}
+
+// isMisuse reports whether code is that of a rule given
+// invalid arguments, or a value of the wrong type.
+func isMisuse(code string) bool {
+	for _, suffix := range []string{".invalid_args", ".invalid_type", ".unknown_pattern"} {
+		if strings.HasSuffix(code, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRules parses the rules of a tag, or of a section of it. A tag which
+// cannot be parsed results in a single node reporting it when run.
+func parseRules(src string) []*node {
+	p := &parser{src: src}
+	terms, err := p.rules()
+	if err == nil && !p.eof() {
+		err = fmt.Errorf("unexpected %q at offset %d", p.src[p.pos], p.pos)
+	}
+	if err != nil {
+		return []*node{{kind: invalidNode, args: src, err: err}}
+	}
+	return terms
+}
+
+type parser struct {
+	src string
+	pos int
+	// aliases lists the aliases being expanded
+	aliases []string
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) peek(s string) bool {
+	return strings.HasPrefix(p.src[p.pos:], s)
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// rules parses terms until the end of the source, or a closing parenthesis.
+// Empty terms are skipped.
+func (p *parser) rules() ([]*node, error) {
+	var terms []*node
+	for {
+		p.skipSpace()
+		switch {
+		case p.eof() || p.peek(")"):
+			return terms, nil
+		case p.peek(","):
+			p.pos++
+			continue
+		}
+		term, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+
+		p.skipSpace()
+		if !p.eof() && !p.peek(",") && !p.peek(")") {
+			return nil, fmt.Errorf("unexpected %q at offset %d", p.src[p.pos], p.pos)
+		}
+	}
+}
+
+func (p *parser) term() (*node, error) {
+	first, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	alternatives := []*node{first}
+	for {
+		p.skipSpace()
+		if !p.peek("||") {
+			break
+		}
+		p.pos += 2
+		alternative, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, alternative)
+	}
+	if len(alternatives) == 1 {
+		return first, nil
+	}
+	return &node{kind: orNode, children: alternatives}, nil
+}
+
+func (p *parser) unary() (*node, error) {
+	p.skipSpace()
+	switch {
+	case p.peek("!"):
+		p.pos++
+		child, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: notNode, children: []*node{child}}, nil
+	case p.peek("("):
+		start := p.pos
+		p.pos++
+		terms, err := p.rules()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peek(")") {
+			return nil, fmt.Errorf("unclosed parenthesis at offset %d", start)
+		}
+		p.pos++
+		switch len(terms) {
+		case 0:
+			return nil, fmt.Errorf("empty parentheses at offset %d", start)
+		case 1:
+			return terms[0], nil
+		}
+		return &node{kind: andNode, children: terms}, nil
+	default:
+		return p.rule()
+	}
+}
+
+func (p *parser) rule() (*node, error) {
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(":,()!| \t'\\", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	if p.pos == start {
+		if p.eof() {
+			return nil, errors.New("unexpected end of tag")
+		}
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.src[p.pos], p.pos)
+	}
+	n := &node{kind: ruleNode, name: p.src[start:p.pos]}
+	if p.peek(":") {
+		p.pos++
+		args, err := p.args()
+		if err != nil {
+			return nil, err
+		}
+		n.args = args
+	}
+	if rules, ok := getAlias(n.name); ok && !isBuiltin(n.name) {
+		return expand(n.name, n.args, rules, p.aliases)
+	}
+	return n, nil
+}
+
+// escaped holds the characters a backslash escapes in arguments.
+// Any other backslash is kept as it is.
+const escaped = ",)|';\\ "
+
+// args reads arguments, unescaping them. Unquoted trailing spaces are trimmed.
+func (p *parser) args() (string, error) {
+	var b strings.Builder
+	// kept is the length of what was escaped or quoted, never trimmed
+	var kept int
+	// quotes only open at the start of an argument
+	start := true
+	for !p.eof() && !p.peek(",") && !p.peek(")") && !p.peek("||") {
+		c := p.src[p.pos]
+		switch {
+		case c == '\\':
+			if p.pos+1 >= len(p.src) {
+				return "", errors.New("unexpected end of tag after backslash")
+			}
+			// other backslashes are kept, as in `regex:^\d+$`
+			if strings.IndexByte(escaped, p.src[p.pos+1]) < 0 {
+				b.WriteByte(c)
+				p.pos++
+				break
+			}
+			b.WriteByte(p.src[p.pos+1])
+			p.pos += 2
+			kept = b.Len()
+		case c == '\'' && start:
+			end := strings.IndexByte(p.src[p.pos+1:], '\'')
+			if end < 0 {
+				return "", fmt.Errorf("unclosed quote at offset %d", p.pos)
+			}
+			b.WriteString(p.src[p.pos+1 : p.pos+1+end])
+			p.pos += end + 2
+			kept = b.Len()
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+		start = c == ':' || c == '|'
+	}
+	args := b.String()
+	return args[:kept] + strings.TrimRight(args[kept:], " \t"), nil
+}
+
+// escapeArgs escapes args, so that they may be written in a tag
+func escapeArgs(args string) string {
+	var b strings.Builder
+	for i := 0; i < len(args); i++ {
+		c := args[i]
+		switch {
+		case strings.IndexByte(",);'\\", c) >= 0,
+			c == '|' && (strings.HasPrefix(args[i+1:], "|") || strings.HasSuffix(args[:i], "|")),
+			c == ' ' && i == len(args)-1:
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// splitSections splits a tag on the semicolons which are neither escaped nor
+// quoted. As in arguments, quotes only open after ":" or "|".
+func splitSections(tag string) []string {
+	var sections []string
+	var quoted, argStart bool
+	start := 0
+	for i := 0; i < len(tag); i++ {
+		switch c := tag[i]; {
+		case quoted:
+			quoted = c != '\''
+		case c == '\\':
+			i, argStart = i+1, false
+			continue
+		case c == '\'' && argStart:
+			quoted = true
+		case c == ';':
+			sections = append(sections, tag[start:i])
+			start = i + 1
+		}
+		argStart = !quoted && (tag[i] == ':' || tag[i] == '|')
+	}
+	return append(sections, tag[start:])
+}
@@ -0,0 +1,149 @@
+package v
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ladydascalie/v/validators"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want []string
+	}{
+		{tag: "required, maxchar:10,", want: []string{"required", "maxchar:10"}},
+		{tag: "in:a|b", want: []string{"in:a|b"}},
+		{tag: "matches:uuid4 || empty_string", want: []string{"(matches:uuid4 || empty_string)"}},
+		{tag: "required,!in:admin|root", want: []string{"required", "!in:admin|root"}},
+		{tag: "(matches:alpha, maxchar:3) || matches:numeric", want: []string{"((matches:alpha, maxchar:3) || matches:numeric)"}},
+		{tag: "!(in:a || in:b)", want: []string{"!(in:a || in:b)"}},
+		{tag: `in:'a,b'|c\,d`, want: []string{`in:a\,b|c\,d`}},
+		{tag: `in:a\|\|b`, want: []string{`in:a\|\|b`}},
+		{tag: `in:don't|x`, want: []string{`in:don\'t|x`}},
+		{tag: `regex:^\d+\.\d*$`, want: []string{`regex:^\\d+\\.\\d*$`}},
+		{tag: `regex:a\\d`, want: []string{`regex:a\\d`}},
+		{tag: `in:x|'a,b'`, want: []string{`in:x|a\,b`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			var got []string
+			for _, rule := range parseRules(tt.tag) {
+				if rule.kind == invalidNode {
+					t.Fatalf("cannot parse: %v", rule.err)
+				}
+				got = append(got, rule.String())
+			}
+			if strings.Join(got, " ; ") != strings.Join(tt.want, " ; ") {
+				t.Errorf("parseRules() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for _, tag := range []string{"(required", "required)", "()", "in:'a", "!", "a || ", `in:a\`} {
+		if rules := parseRules(tag); len(rules) != 1 || rules[0].kind != invalidNode {
+			t.Errorf("expected %q to be invalid", tag)
+		}
+	}
+}
+
+func TestStruct_compositions(t *testing.T) {
+	type user struct {
+		ID   string `v:"matches:uuid4 || empty_string"`
+		Role string `v:"!in:admin|root"`
+		Code string `v:"(matches:alpha, maxchar:3) || matches:numeric"`
+		Tag  string `v:"in:'a,b'|c"`
+		Word string `v:"in:don't|won't;required"`
+	}
+	valid := []user{
+		{ID: "", Role: "user", Code: "abc", Tag: "a,b", Word: "don't"},
+		{ID: "0b5b5f13-5d33-4a54-8d0a-7c1a2c1e5b1d", Role: "", Code: "12345", Tag: "c", Word: "won't"},
+	}
+	for _, u := range valid {
+		if err := Struct(u); err != nil {
+			t.Errorf("unexpected error for %+v: %v", u, err)
+		}
+	}
+
+	err := Struct(user{ID: "nope", Role: "root", Code: "abcd", Tag: "a", Word: "don't"})
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 4 {
+		t.Fatalf("expected 4 errors, got %v", err)
+	}
+	codes := []string{validators.CodeOrNoMatch, validators.CodeNotMatched, validators.CodeOrNoMatch, validators.CodeInNotAllowed}
+	for i, code := range codes {
+		if got := errs[i].(ErrorValidation).Code(); got != code {
+			t.Errorf("errors[%d].Code() = %s, want %s", i, got, code)
+		}
+	}
+	if msg := errs[0].Error(); !strings.Contains(msg, "matches:uuid4: cannot validate data as uuid4") || !strings.Contains(msg, "empty_string") {
+		t.Errorf("expected the failed alternatives to be explained, got %s", msg)
+	}
+
+	// misused rules are reported rather than negated
+	if err := validate("!matches:unknown", "x", nil); err == nil {
+		t.Error("expected an unknown pattern to be reported")
+	}
+	if err := validate("!func:not_registered", "x", nil); err == nil {
+		t.Error("expected a missing custom validator to be reported")
+	}
+}
+
+func TestStruct_notCustom(t *testing.T) {
+	validators.CustomFuncMap.Set("is_admin", func(_ string, value, _ interface{}) error {
+		if value != "admin" {
+			return errors.New("not an admin")
+		}
+		return nil
+	})
+	type user struct {
+		Name string `v:"!func:is_admin"`
+	}
+	if err := Struct(user{Name: "someone"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := Struct(user{Name: "admin"})
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 1 || errs[0].(ErrorValidation).Code() != validators.CodeNotMatched {
+		t.Errorf("expected a not_matched error, got %v", err)
+	}
+}
+
+func TestStruct_regex(t *testing.T) {
+	type post struct {
+		Slug string `v:"regex:'^[a-z]+(-[a-z]+)*$'"`
+		Code string `v:"regex:^[0-9]{2\\,4}$"`
+		Zip  string `v:"regex:^\\d{5}$"`
+	}
+	if err := Struct(post{Slug: "a-slug", Code: "123", Zip: "75001"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := Struct(post{Slug: "Not a slug", Code: "12345", Zip: "d5"})
+	if errs, ok := err.(Errors); !ok || len(errs) != 3 {
+		t.Errorf("expected 3 errors, got %v", err)
+	}
+}
+
+func TestStruct_unquotedRegex(t *testing.T) {
+	tests := []struct {
+		name      string
+		structure interface{}
+		want      string
+	}{
+		{name: "comma", structure: struct {
+			Code string `v:"regex:^\\d{1,3}$"`
+		}{Code: "12"}, want: "unknown rule 3}$"},
+		{name: "parenthesis", structure: struct {
+			Code string `v:"regex:^(a|b)$"`
+		}{Code: "a"}, want: "cannot parse regex:^(a|b)$"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.structure)
+			if err == nil || !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), "must be quoted") {
+				t.Errorf("expected %q to be reported, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package v
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// groupNames holds the groups rules may be scoped to
+var groupNames = struct {
+	sync.RWMutex
+	names map[string]bool
+}{names: make(map[string]bool)}
+
+// RegisterGroup declares groups, so that sections of tags may be scoped
+// to them, such as `v:"create:forbidden;update:required"`. A section
+// starting with the name of an undeclared group is read as a rule, and
+// fails as an unknown one: declare groups at init time, before validating
+// any type using them. Errors are returned for invalid names, and for
+// names of rules or aliases.
+func RegisterGroup(names ...string) error {
+	for _, name := range names {
+		switch {
+		case name == "" || strings.ContainsAny(name, ":,()!|;'\\ \t"):
+			return fmt.Errorf("invalid group name %q", name)
+		case isRule(name):
+			return fmt.Errorf("group %s would shadow a rule", name)
+		}
+	}
+
+	groupNames.Lock()
+	for _, name := range names {
+		groupNames.names[name] = true
+	}
+	groupNames.Unlock()
+
+	// types compile again on their next validation
+	plans.Range(func(t, _ interface{}) bool {
+		plans.Delete(t)
+		return true
+	})
+	return nil
+}
+
+// isGroup reports whether name is that of a declared group
+func isGroup(name string) bool {
+	groupNames.RLock()
+	defer groupNames.RUnlock()
+	return groupNames.names[name]
+}
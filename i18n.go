@@ -0,0 +1,122 @@
+package v
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/ladydascalie/v/catalog"
+	"github.com/ladydascalie/v/validators"
+)
+
+type localeKey struct{}
+
+// WithLocale returns a copy of ctx carrying the locale
+// StructContext renders its error messages in.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFrom returns the locale set on ctx, if any
+func LocaleFrom(ctx context.Context) (locale string, ok bool) {
+	locale, ok = ctx.Value(localeKey{}).(string)
+	return
+}
+
+// Localize returns a copy of err whose messages are rendered in locale,
+// for when the locale is only known after validating.
+func Localize(err error, locale string) error {
+	switch e := err.(type) {
+	case Errors:
+		localized := make(Errors, len(e))
+		for i, err := range e {
+			localized[i] = Localize(err, locale)
+		}
+		return localized
+	case ErrorValidation:
+		e.Locale = locale
+		return e
+	case ErrorRequired:
+		e.Locale = locale
+		return e
+	default:
+		return err
+	}
+}
+
+// translator carries what errors need to render their messages
+type translator struct {
+	locale   string
+	messages *catalog.Catalog
+	labels   LabelFunc
+}
+
+// LabelFunc returns the human-facing name of a field, or an empty string
+// if it has none. Labels may be catalog keys, see LabelKey.
+type LabelFunc func(field reflect.StructField) string
+
+// LabelTag reads labels from the `label` struct tag
+func LabelTag(field reflect.StructField) string {
+	return field.Tag.Get(labeltag)
+}
+
+// LabelKey returns the catalog key under which the translations of
+// label are looked up. For instance, label:"first_name" is translated
+// by the "label.first_name" template of the locale.
+func LabelKey(label string) string {
+	return "label." + label
+}
+
+// displayName returns the name of a field as shown to humans: its label,
+// translated if the catalog knows about it, then its json name, then its Go name.
+func displayName(messages *catalog.Catalog, locale, label, jsonName, name string) string {
+	if label != "" {
+		if messages == nil {
+			messages = catalog.Default
+		}
+		if translated, ok := messages.Render(locale, LabelKey(label), catalog.Data{Field: label}); ok {
+			return translated
+		}
+		return label
+	}
+	if jsonName != "" {
+		return jsonName
+	}
+	return name
+}
+
+// localize renders the message of err for the named field.
+// Any translation missing from the catalog falls back on English.
+func localize(messages *catalog.Catalog, locale, field string, err error) string {
+	if messages == nil {
+		messages = catalog.Default
+	}
+	data := catalog.Data{Field: field, Message: message(messages, locale, field, err)}
+	if message, ok := messages.Render(locale, catalog.FieldKey, data); ok {
+		return message
+	}
+	return fmt.Sprintf("[validation] %s: %s", field, data.Message)
+}
+
+// message renders the message of err alone, without the field name
+func message(messages *catalog.Catalog, locale, field string, err error) string {
+	if messages == nil {
+		messages = catalog.Default
+	}
+	var verr validators.Error
+	if locale != "" && errors.As(err, &verr) {
+		data := catalog.Data{Field: field, Params: verr.Params, Value: verr.Value, Message: err.Error()}
+		if message, ok := messages.Render(locale, verr.Code, data); ok {
+			return message
+		}
+	}
+	return err.Error()
+}
+
+func (tr translator) label(field reflect.StructField) string {
+	if tr.labels == nil {
+		return ""
+	}
+	return tr.labels(field)
+}
@@ -0,0 +1,202 @@
+// Package modifiers holds the transforms applied by the `mod` tag,
+// which sanitize and normalize string fields before they are validated.
+package modifiers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/ladydascalie/v/validators"
+	"golang.org/x/text/unicode/norm"
+)
+
+const tagname = "mod"
+
+// Modifier transforms a value, args being the arguments set in the tag
+type Modifier func(args string, value string) string
+
+type customFuncMap struct {
+	rw        sync.RWMutex
+	modifiers map[string]Modifier
+}
+
+// GetFuncMap returns the custom func map
+func GetFuncMap() *customFuncMap {
+	return CustomFuncMap
+}
+
+func (c *customFuncMap) Set(tag string, modifier Modifier) {
+	c.rw.Lock()
+	c.modifiers[tag] = modifier
+	c.rw.Unlock()
+}
+
+func (c *customFuncMap) Get(tag string) (modifier Modifier, ok bool) {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	modifier, ok = c.modifiers[tag]
+	return
+}
+
+// CustomFuncMap should be used to access and add new modifiers
+var CustomFuncMap = &customFuncMap{modifiers: make(map[string]Modifier)}
+
+// FuncMap defines where all the built-in modifiers live
+var FuncMap = map[string]Modifier{
+	"trim":          Trim,
+	"lower":         Lower,
+	"upper":         Upper,
+	"collapse":      Collapse,
+	"nfc":           NFC,
+	"strip_control": StripControl,
+	"e164":          E164,
+}
+
+// Trim removes leading and trailing white space
+func Trim(_ string, value string) string {
+	return strings.TrimSpace(value)
+}
+
+// Lower maps the value to lower case
+func Lower(_ string, value string) string {
+	return strings.ToLower(value)
+}
+
+// Upper maps the value to upper case
+func Upper(_ string, value string) string {
+	return strings.ToUpper(value)
+}
+
+// Collapse replaces each run of white space with a single space,
+// trimming it at both ends of the value.
+func Collapse(_ string, value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// NFC applies the Unicode Normalization Form C,
+// so that equivalent strings are made of the same code points.
+func NFC(_ string, value string) string {
+	return norm.NFC.String(value)
+}
+
+// StripControl removes control characters
+func StripControl(_ string, value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// E164 rewrites phone numbers into the E.164 format, such as +33123456789.
+// National numbers are read as numbers of the countries given as arguments,
+// such as `mod:"e164:FR"`. Invalid numbers are left unchanged, for the
+// `phone` rule to report. See validators.Phone.
+func E164(args string, value string) string {
+	var countries []string
+	if args != "" {
+		countries = strings.Split(args, "|")
+	}
+	if e164, ok := validators.NormalizePhone(value, countries...); ok {
+		return e164
+	}
+	return value
+}
+
+// Struct applies the `mod` tags of the struct ptr points to, in order.
+// Tags hold comma separated modifiers, such as `mod:"trim,lower"`.
+// They apply to strings, pointers to strings and slices of strings.
+// Nested structs are walked.
+func Struct(ptr interface{}) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("only pointers to structs may be modified")
+	}
+	return modifyStruct(rv.Elem())
+}
+
+func modifyStruct(rv reflect.Value) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := rv.Field(i)
+
+		// unexported fields cannot be set
+		if field.PkgPath != "" {
+			continue
+		}
+
+		// retrieve the underlying value if possible
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+
+		if value.Kind() == reflect.Struct {
+			if err := modifyStruct(value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tag := field.Tag.Get(tagname)
+		if tag == "" {
+			continue
+		}
+		if err := modify(tag, value); err != nil {
+			return fmt.Errorf("%s: %v", field.Name, err)
+		}
+	}
+	return nil
+}
+
+func modify(tag string, value reflect.Value) error {
+	switch {
+	case value.Kind() == reflect.String:
+		s, err := apply(tag, value.String())
+		if err != nil {
+			return err
+		}
+		value.SetString(s)
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String:
+		for i := 0; i < value.Len(); i++ {
+			if err := modify(tag, value.Index(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("modifiers can only operate on strings, or []string, got %s", value.Type())
+	}
+	return nil
+}
+
+// apply runs the modifiers of tag over value
+func apply(tag, value string) (string, error) {
+	for _, mod := range strings.Split(tag, ",") {
+		mod = strings.TrimSpace(mod)
+		if mod == "" {
+			continue
+		}
+		name, args := mod, ""
+		if i := strings.Index(mod, ":"); i >= 0 {
+			name, args = mod[:i], mod[i+1:]
+		}
+
+		modifier, ok := FuncMap[name]
+		if !ok {
+			modifier, ok = CustomFuncMap.Get(name)
+		}
+		if !ok {
+			return "", fmt.Errorf("modifier %s did not match any available function", name)
+		}
+		value = modifier(args, value)
+	}
+	return value, nil
+}
@@ -0,0 +1,100 @@
+package modifiers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModifiers(t *testing.T) {
+	tests := []struct {
+		name     string
+		modifier Modifier
+		value    string
+		want     string
+	}{
+		{"trim", Trim, " \t hello \n", "hello"},
+		{"lower", Lower, "Someone@GMAIL.com", "someone@gmail.com"},
+		{"upper", Upper, "fr", "FR"},
+		{"collapse", Collapse, "  hello \t\n  world ", "hello world"},
+		{"nfc", NFC, "e\u0301", "\u00e9"},
+		{"strip_control", StripControl, "he\x00llo\x7f\n", "hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.modifier("", tt.value); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestE164(t *testing.T) {
+	tests := []struct {
+		args, value, want string
+	}{
+		{"FR", "01 23 45 67 89", "+33123456789"},
+		{"US|CA", "(202) 555-0143", "+12025550143"},
+		{"", "+44 20 7946 0000", "+442079460000"},
+		{"FR", "not a number", "not a number"},
+		{"", "01 23 45 67 89", "01 23 45 67 89"},
+	}
+	for _, tt := range tests {
+		if got := E164(tt.args, tt.value); got != tt.want {
+			t.Errorf("E164(%q, %q) = %q, want %q", tt.args, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestStruct(t *testing.T) {
+	CustomFuncMap.Set("slug", func(_ string, value string) string {
+		return strings.Replace(value, " ", "-", -1)
+	})
+
+	type inner struct {
+		Email string `mod:"trim,lower"`
+	}
+	type outer struct {
+		Name    *string  `mod:"collapse"`
+		Tags    []string `mod:"trim, slug"`
+		Inner   inner
+		Ptr     *inner
+		Nil     *inner
+		private string `mod:"trim"`
+	}
+	name := "  Jane   Doe "
+	s := outer{
+		Name:    &name,
+		Tags:    []string{" a tag "},
+		Inner:   inner{Email: " Jane@Example.COM"},
+		Ptr:     &inner{Email: "X@Y.Z "},
+		private: " kept ",
+	}
+	if err := Struct(&s); err != nil {
+		t.Fatal(err)
+	}
+	if *s.Name != "Jane Doe" || s.Tags[0] != "a-tag" || s.Inner.Email != "jane@example.com" || s.Ptr.Email != "x@y.z" || s.private != " kept " {
+		t.Errorf("unexpected result: %q %q %+v %+v %q", *s.Name, s.Tags, s.Inner, s.Ptr, s.private)
+	}
+}
+
+func TestStruct_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		ptr  interface{}
+	}{
+		{"not a pointer", struct{}{}},
+		{"unknown modifier", &struct {
+			S string `mod:"gibberish"`
+		}{}},
+		{"unsupported type", &struct {
+			I int `mod:"trim"`
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Struct(tt.ptr); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// docReader extracts doc comments from the sources of the packages
+// the generated types live in, as loaded by go/packages. It only reads
+// local files, so it works offline for any package within reach of the
+// module.
+type docReader struct {
+	// packages maps an import path onto the comments of its types,
+	// which in turn map field names onto their comments.
+	// The type's own comment is stored under the empty field name.
+	packages map[string]map[string]map[string]string
+	// errs holds the errors packages failed to load with
+	errs map[string]error
+}
+
+func newDocReader() *docReader {
+	return &docReader{
+		packages: make(map[string]map[string]map[string]string),
+		errs:     make(map[string]error),
+	}
+}
+
+func (d *docReader) typeDoc(dir string, t reflect.Type) (string, error) {
+	docs, err := d.lookup(dir, t)
+	return docs[""], err
+}
+
+func (d *docReader) fieldDoc(dir string, t reflect.Type, field string) (string, error) {
+	docs, err := d.lookup(dir, t)
+	return docs[field], err
+}
+
+func (d *docReader) lookup(dir string, t reflect.Type) (map[string]string, error) {
+	if t.PkgPath() == "" || t.Name() == "" {
+		return nil, nil
+	}
+	path := t.PkgPath()
+	if err, failed := d.errs[path]; failed {
+		return nil, err
+	}
+	types, ok := d.packages[path]
+	if !ok {
+		var err error
+		if types, err = loadPackage(dir, path); err != nil {
+			err = fmt.Errorf("openapi: loading the doc comments of %s: %v", path, err)
+			d.errs[path] = err
+			return nil, err
+		}
+		d.packages[path] = types
+	}
+	return types[t.Name()], nil
+}
+
+// loadPackage parses the sources of the package, test files included,
+// since types may be declared there too.
+func loadPackage(dir, importPath string) (map[string]map[string]string, error) {
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax,
+		Dir:   dir,
+		Tests: true,
+	}
+	pkgs, err := packages.Load(cfg, importPath)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[string]map[string]string)
+	for _, pkg := range pkgs {
+		if pkg.PkgPath != importPath {
+			continue
+		}
+		if len(pkg.Errors) > 0 {
+			return nil, pkg.Errors[0]
+		}
+		for _, file := range pkg.Syntax {
+			collectDocs(file, types)
+		}
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("no sources found from %q", cfg.Dir)
+	}
+	return types, nil
+}
+
+// collectDocs adds the comments of the types declared in file to types
+func collectDocs(file *ast.File, types map[string]map[string]string) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			docs := map[string]string{"": comment(ts.Doc, gen.Doc)}
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				for _, field := range st.Fields.List {
+					for _, name := range field.Names {
+						docs[name.Name] = comment(field.Doc, field.Comment)
+					}
+				}
+			}
+			types[ts.Name.Name] = docs
+		}
+	}
+}
+
+// comment returns the text of the first non-empty comment group
+func comment(groups ...*ast.CommentGroup) string {
+	for _, group := range groups {
+		if text := strings.TrimSpace(group.Text()); text != "" {
+			return text
+		}
+	}
+	return ""
+}
@@ -0,0 +1,390 @@
+// Package openapi generates OpenAPI 3.1 component schemas from Go types,
+// mapping their `v` validation rules and `json` names into JSON Schema
+// keywords, and their doc comments into descriptions.
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ladydascalie/v"
+	"github.com/ladydascalie/v/validators"
+)
+
+const (
+	tagname = "v"
+	jsontag = "json"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Generator collects the schemas of the types added to it.
+// It is not safe for concurrent use.
+type Generator struct {
+	// Dir is the directory from which package sources are looked up
+	// when extracting doc comments. It defaults to the working directory.
+	// Types whose sources cannot be found there fail to be added.
+	Dir string
+
+	docs    *docReader
+	names   map[reflect.Type]string
+	schemas map[string]*Schema
+	order   []string
+}
+
+// New returns an empty Generator
+func New() *Generator {
+	return &Generator{
+		docs:    newDocReader(),
+		names:   make(map[reflect.Type]string),
+		schemas: make(map[string]*Schema),
+	}
+}
+
+// Add registers the schema of each given value's type, along with the
+// schemas of any named struct types they reference.
+func (g *Generator) Add(values ...interface{}) error {
+	for _, value := range values {
+		if value == nil {
+			return errors.New("openapi: cannot generate a schema for nil")
+		}
+		t := reflect.TypeOf(value)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || t.Name() == "" {
+			return fmt.Errorf("openapi: only named struct types may be added, got %s", t)
+		}
+		if _, err := g.ref(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Components returns the `components` object of an OpenAPI document.
+func (g *Generator) Components() *Components {
+	schemas := make(Properties, 0, len(g.order))
+	for _, name := range g.order {
+		schemas = append(schemas, Property{Name: name, Schema: g.schemas[name]})
+	}
+	return &Components{Schemas: schemas}
+}
+
+// JSON renders the components as an indented JSON document.
+func (g *Generator) JSON() ([]byte, error) {
+	return json.MarshalIndent(document{Components: g.Components()}, "", "  ")
+}
+
+// YAML renders the components as a YAML document.
+func (g *Generator) YAML() ([]byte, error) {
+	data, err := json.Marshal(document{Components: g.Components()})
+	if err != nil {
+		return nil, err
+	}
+	return toYAML(data)
+}
+
+type document struct {
+	Components *Components `json:"components"`
+}
+
+// ref returns a reference to the named struct type, generating its schema
+// on first use.
+func (g *Generator) ref(t reflect.Type) (*Schema, error) {
+	name, ok := g.names[t]
+	if !ok {
+		name = g.schemaName(t)
+		g.names[t] = name
+		g.order = append(g.order, name)
+
+		// register the schema before walking its fields,
+		// so that recursive types resolve to a reference
+		doc, err := g.docs.typeDoc(g.Dir, t)
+		if err != nil {
+			return nil, err
+		}
+		schema := &Schema{Type: "object", Description: doc}
+		g.schemas[name] = schema
+		if err := g.fields(t, schema); err != nil {
+			return nil, err
+		}
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}, nil
+}
+
+// schemaName picks a component name for t, qualifying it with its
+// package name should two packages export the same type name.
+func (g *Generator) schemaName(t reflect.Type) string {
+	name := t.Name()
+	if _, taken := g.schemas[name]; !taken {
+		return name
+	}
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	return pkg + "." + name
+}
+
+func (g *Generator) object(t reflect.Type) (*Schema, error) {
+	schema := &Schema{Type: "object"}
+	if err := g.fields(t, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// fields adds the properties of struct type t to schema. Embedded
+// structs without a json name are flattened, as encoding/json does.
+func (g *Generator) fields(t reflect.Type, schema *Schema) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, opts := parseJSONTag(field.Tag.Get(jsontag))
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if field.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			if err := g.fields(ft, schema); err != nil {
+				return err
+			}
+			continue
+		}
+		// only exported fields are encoded
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop, err := g.schema(field.Type)
+		if err != nil {
+			return fmt.Errorf("openapi: %s.%s: %v", t.Name(), field.Name, err)
+		}
+		if strings.Contains(opts, "string") && prop.Ref == "" {
+			prop = &Schema{Type: "string"}
+		}
+
+		required, err := applyRules(prop, field.Tag.Get(tagname))
+		if err != nil {
+			return fmt.Errorf("openapi: %s.%s: %v", t.Name(), field.Name, err)
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+
+		// siblings of $ref are allowed as of OpenAPI 3.1
+		if prop.Description, err = g.docs.fieldDoc(g.Dir, t, field.Name); err != nil {
+			return err
+		}
+		schema.Properties = append(schema.Properties, Property{Name: name, Schema: prop})
+	}
+	return nil
+}
+
+// schema maps a Go type onto its JSON Schema counterpart.
+func (g *Generator) schema(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}, nil
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}, nil
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}, nil
+		}
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map keys must be strings, got %s", t.Key())
+		}
+		values, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return g.ref(t)
+	case reflect.Interface:
+		return &Schema{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// applyRules translates the `v` tag of a field into schema keywords,
+// and reports whether the field is required. Rules scoped to groups are ignored.
+func applyRules(schema *Schema, tag string) (required bool, err error) {
+	for _, rule := range v.GroupRules(tag) {
+		// compositions of rules have no equivalent keywords
+		if strings.HasPrefix(rule, "(") || strings.HasPrefix(rule, "!") {
+			continue
+		}
+		name, args := rule, ""
+		if i := strings.Index(rule, ":"); i >= 0 {
+			name, args = rule[:i], rule[i+1:]
+		}
+
+		// rules on a []string apply to each of its items
+		target := schema
+		if schema.Type == "array" && schema.Items != nil && schema.Items.Type == "string" && name != "required" {
+			target = schema.Items
+		}
+
+		switch name {
+		case "required":
+			required = true
+		case "maxchar":
+			max, err := strconv.Atoi(args)
+			if err != nil {
+				return false, fmt.Errorf("maxchar requires an integer as a parameter")
+			}
+			target.MaxLength = &max
+		case "between":
+			min, max, err := bounds(args)
+			if err != nil {
+				return false, err
+			}
+			if target.Type == "string" {
+				target.MinLength, target.MaxLength = intPtr(min), intPtr(max)
+			} else {
+				target.Minimum, target.Maximum = min, max
+			}
+		case "empty_string":
+			zero := 0
+			target.MaxLength = &zero
+		case "in":
+			for _, accepted := range strings.Split(args, "|") {
+				if target.Type == "integer" || target.Type == "number" {
+					f, err := strconv.ParseFloat(accepted, 64)
+					if err != nil {
+						return false, fmt.Errorf("in requires numeric parameters to check for numeric values: %v", err)
+					}
+					target.Enum = append(target.Enum, f)
+					continue
+				}
+				target.Enum = append(target.Enum, accepted)
+			}
+		case "is_int64":
+			target.Pattern = validators.Int
+		case "is_float64":
+			target.Pattern = validators.Float
+		case "matches":
+			if format, ok := formats[args]; ok {
+				target.Format = format
+				continue
+			}
+			exp, ok := validators.Pattern(args)
+			if !ok {
+				return false, fmt.Errorf("no regex found for matcher: %s", args)
+			}
+			target.Pattern = exp.String()
+		case "regex":
+			target.Pattern = args
+		case "ipv4", "ipv6":
+			target.Format = name
+		case "fqdn":
+			target.Format = "hostname"
+		case "url":
+			target.Format = "uri"
+		case "email":
+			target.Format = "email"
+		case "rfc3339":
+			target.Format = "date-time"
+		case "url_relative":
+			target.Format = "uri-reference"
+		case "url_max":
+			max, err := strconv.Atoi(args)
+			if err != nil {
+				return false, fmt.Errorf("url_max requires an integer as a parameter")
+			}
+			target.MaxLength = &max
+		}
+	}
+	return required, nil
+}
+
+// formats maps matchers onto their well-known JSON Schema formats.
+var formats = map[string]string{
+	"email":    "email",
+	"uuid":     "uuid",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"url":      "uri",
+	"dns_name": "hostname",
+}
+
+// bounds parses a `between` range, leaving wildcard ends unset.
+func bounds(s string) (min, max *float64, err error) {
+	parts := strings.Split(s, "..")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, nil, fmt.Errorf("invalid range statement: %v", s)
+	}
+	parse := func(part string) (*float64, error) {
+		if part == "*" {
+			return nil, nil
+		}
+		f, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &f, nil
+	}
+	if min, err = parse(parts[0]); err != nil {
+		return nil, nil, err
+	}
+	if max, err = parse(parts[1]); err != nil {
+		return nil, nil, err
+	}
+	return min, max, nil
+}
+
+func intPtr(f *float64) *int {
+	if f == nil {
+		return nil
+	}
+	i := int(*f)
+	return &i
+}
+
+func parseJSONTag(tag string) (name, opts string) {
+	if i := strings.Index(tag, ","); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
@@ -0,0 +1,178 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ladydascalie/v"
+)
+
+// Address is where a person lives.
+type Address struct {
+	// Street holds the street name and number.
+	Street string `json:"street" v:"required,maxchar:255"`
+	Zip    string `json:"zip" v:"matches:numeric"` // Zip is the postal code.
+}
+
+// Person is someone we know about.
+type Person struct {
+	Name    string   `json:"name" v:"required,between:1..64"`
+	Email   string   `json:"email,omitempty" v:"matches:email"`
+	Age     int      `json:"age" v:"between:21..*"`
+	Role    string   `json:"role" v:"in:admin|user"`
+	Host    string   `json:"host" v:"fqdn"`
+	Website string   `json:"website" v:"url:https,url_max:2048"`
+	Tags    []string `json:"tags" v:"maxchar:10"`
+	Home    *Address `json:"home"`
+	Friends []Person `json:"friends"`
+	Secret  string   `json:"-"`
+	private string
+}
+
+func TestGenerator(t *testing.T) {
+	g := New()
+	if err := g.Add(Person{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := g.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				Description string                 `json:"description"`
+				Required    []string               `json:"required"`
+				Properties  map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	person, ok := doc.Components.Schemas["Person"]
+	if !ok {
+		t.Fatalf("expected a Person schema in %s", data)
+	}
+	if person.Description != "Person is someone we know about." {
+		t.Errorf("unexpected description: %q", person.Description)
+	}
+	if strings.Join(person.Required, ",") != "name" {
+		t.Errorf("unexpected required list: %v", person.Required)
+	}
+	for _, name := range []string{"Secret", "private", "-"} {
+		if _, ok := person.Properties[name]; ok {
+			t.Errorf("property %s should not be exported", name)
+		}
+	}
+
+	tests := []struct {
+		schema, property, want string
+	}{
+		{"Person", "name", `{"type":"string","minLength":1,"maxLength":64}`},
+		{"Person", "email", `{"type":"string","format":"email"}`},
+		{"Person", "age", `{"type":"integer","format":"int64","minimum":21}`},
+		{"Person", "role", `{"type":"string","enum":["admin","user"]}`},
+		{"Person", "host", `{"type":"string","format":"hostname"}`},
+		{"Person", "website", `{"type":"string","format":"uri","maxLength":2048}`},
+		{"Person", "tags", `{"type":"array","items":{"type":"string","maxLength":10}}`},
+		{"Person", "home", `{"$ref":"#/components/schemas/Address"}`},
+		{"Person", "friends", `{"type":"array","items":{"$ref":"#/components/schemas/Person"}}`},
+		{"Address", "street", `{"type":"string","description":"Street holds the street name and number.","maxLength":255}`},
+		{"Address", "zip", `{"type":"string","description":"Zip is the postal code.","pattern":"^[0-9]+$"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schema+"."+tt.property, func(t *testing.T) {
+			got, err := json.Marshal(doc.Components.Schemas[tt.schema].Properties[tt.property])
+			if err != nil {
+				t.Fatal(err)
+			}
+			// round trip the expectation too, so that key order does not matter
+			var want interface{}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			wantData, _ := json.Marshal(want)
+			if string(got) != string(wantData) {
+				t.Errorf("got %s, want %s", got, wantData)
+			}
+		})
+	}
+}
+
+func TestGenerator_YAML(t *testing.T) {
+	g := New()
+	if err := g.Add(&Address{}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := g.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `components:
+  schemas:
+    Address:
+      type: object
+      description: Address is where a person lives.
+      properties:
+        street:
+          type: string
+          description: Street holds the street name and number.
+          maxLength: 255
+        zip:
+          type: string
+          description: Zip is the postal code.
+          pattern: "^[0-9]+$"
+      required:
+        - street
+`
+	if string(data) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestGenerator_Add(t *testing.T) {
+	for _, value := range []interface{}{nil, 42, struct{}{}} {
+		if err := New().Add(value); err == nil {
+			t.Errorf("expected an error when adding %#v", value)
+		}
+	}
+}
+
+func TestGenerator_missingSources(t *testing.T) {
+	g := New()
+	g.Dir = t.TempDir()
+	err := g.Add(Address{})
+	if err == nil || !strings.Contains(err.Error(), "doc comments of github.com/ladydascalie/v/openapi") {
+		t.Errorf("expected an error loading the sources of Address, got: %v", err)
+	}
+}
+
+func TestGenerator_aliases(t *testing.T) {
+	if err := v.Alias("openapi_name", "required,maxchar:$1"); err != nil {
+		t.Fatal(err)
+	}
+	type Pet struct {
+		Name string `json:"name" v:"openapi_name:32"`
+		Kind string `json:"kind" v:"in:cat|dog || empty_string"`
+	}
+	g := New()
+	if err := g.Add(Pet{}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := g.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"maxLength": 32`, `"required": [`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in %s", want, data)
+		}
+	}
+	if strings.Contains(string(data), "enum") {
+		t.Errorf("expected compositions to be left out of %s", data)
+	}
+}
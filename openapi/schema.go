@@ -0,0 +1,64 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Components is the `components` object of an OpenAPI document
+type Components struct {
+	Schemas Properties `json:"schemas"`
+}
+
+// Schema is the subset of the OpenAPI 3.1 Schema Object
+// which `v` rules can be expressed in.
+type Schema struct {
+	Ref                  string        `json:"$ref,omitempty"`
+	Type                 string        `json:"type,omitempty"`
+	Format               string        `json:"format,omitempty"`
+	Description          string        `json:"description,omitempty"`
+	Properties           Properties    `json:"properties,omitempty"`
+	Required             []string      `json:"required,omitempty"`
+	Items                *Schema       `json:"items,omitempty"`
+	AdditionalProperties *Schema       `json:"additionalProperties,omitempty"`
+	Enum                 []interface{} `json:"enum,omitempty"`
+	Minimum              *float64      `json:"minimum,omitempty"`
+	Maximum              *float64      `json:"maximum,omitempty"`
+	MinLength            *int          `json:"minLength,omitempty"`
+	MaxLength            *int          `json:"maxLength,omitempty"`
+	Pattern              string        `json:"pattern,omitempty"`
+}
+
+// Property is a named schema
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// Properties is a list of schemas which encodes to a JSON object,
+// keeping the order in which the properties were declared.
+type Properties []Property
+
+// MarshalJSON satisfies the json.Marshaler interface
+func (p Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, prop := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(prop.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(prop.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
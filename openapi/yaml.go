@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// toYAML converts a JSON document into its block-style YAML equivalent,
+// keeping object keys in the order they were encoded in.
+func toYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	if err := writeYAML(&buf, dec, 0, false); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeYAML writes the next JSON value from dec. inline reports whether
+// the value follows a key or a sequence dash on the current line.
+func writeYAML(buf *bytes.Buffer, dec *json.Decoder, depth int, inline bool) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	indent := strings.Repeat("  ", depth)
+
+	switch tok {
+	case json.Delim('{'):
+		if !dec.More() {
+			buf.WriteString(" {}\n")
+			_, err = dec.Token()
+			return err
+		}
+		if inline {
+			buf.WriteByte('\n')
+		}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(buf, "%s%s:", indent, scalar(key.(string)))
+			if err := writeYAML(buf, dec, depth+1, true); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+		return err
+	case json.Delim('['):
+		if !dec.More() {
+			buf.WriteString(" []\n")
+			_, err = dec.Token()
+			return err
+		}
+		if inline {
+			buf.WriteByte('\n')
+		}
+		for dec.More() {
+			fmt.Fprintf(buf, "%s-", indent)
+			if err := writeYAML(buf, dec, depth+1, true); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+		return err
+	}
+
+	switch v := tok.(type) {
+	case string:
+		fmt.Fprintf(buf, " %s\n", scalar(v))
+	case json.Number:
+		fmt.Fprintf(buf, " %s\n", v)
+	case bool:
+		fmt.Fprintf(buf, " %t\n", v)
+	case nil:
+		buf.WriteString(" null\n")
+	}
+	return nil
+}
+
+// scalar quotes s whenever YAML could read it as anything but a plain string
+func scalar(s string) string {
+	if s == "" || strings.ContainsAny(s, ":#{}[],&*!|>'\"%@`\\\n\t") ||
+		strings.TrimSpace(s) != s || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "?") {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package v
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// StructPartial is like Struct, only validating the fields at the given
+// dotted paths, written with either their json or Go names, and all the
+// fields of the structs they hold. The structs leading to these fields are
+// validated as well, but not their other fields: listing "address.city"
+// validates address and its city, not its zip code, while listing "address"
+// validates both.
+//
+// Paired with PresentFields, it validates JSON merge patches:
+//
+//	fields, err := v.PresentFields(body)
+//	...
+//	err = v.StructPartial(patch, fields...)
+func StructPartial(structure interface{}, fields ...string) error {
+	return std.StructPartial(structure, fields...)
+}
+
+// StructExcept is like Struct, skipping the fields at the given dotted
+// paths, written with either their json or Go names, and all the fields
+// of the structs they hold.
+func StructExcept(structure interface{}, fields ...string) error {
+	return std.StructExcept(structure, fields...)
+}
+
+// StructPartial is like Struct, only validating the fields at the given paths.
+// See the package level StructPartial.
+func (val *Validator) StructPartial(structure interface{}, fields ...string) error {
+	// listed fields map to true, the structs leading to them to false
+	only := make(map[string]bool)
+	for _, field := range fields {
+		for i, r := range field {
+			if r != '.' {
+				continue
+			}
+			if _, ok := only[field[:i]]; !ok {
+				only[field[:i]] = false
+			}
+		}
+		only[field] = true
+	}
+	sc := val.scope()
+	sc.only = only
+	return validateStruct(structure, location{}, sc)
+}
+
+// StructExcept is like Struct, skipping the fields at the given paths.
+// See the package level StructExcept.
+func (val *Validator) StructExcept(structure interface{}, fields ...string) error {
+	except := make(map[string]bool)
+	for _, field := range fields {
+		except[field] = true
+	}
+	sc := val.scope()
+	sc.except = except
+	return validateStruct(structure, location{}, sc)
+}
+
+// PresentFields returns the dotted paths of the members of the JSON
+// object in data, sorted. Nested objects are walked rather than listed,
+// so that StructPartial validates the members they hold, not the ones
+// they leave out: the structs leading to these are validated all the same.
+// Empty objects hold no members, and are listed. Arrays are not walked.
+func PresentFields(data []byte) ([]string, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	if object == nil {
+		return nil, errors.New("expected a JSON object")
+	}
+	var fields []string
+	presentFields(object, "", &fields)
+	sort.Strings(fields)
+	return fields, nil
+}
+
+func presentFields(object map[string]interface{}, path string, fields *[]string) {
+	for name, value := range object {
+		name = joinPath(path, name)
+		// an empty object has no members to lead StructPartial to its own rules
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			presentFields(nested, name, fields)
+			continue
+		}
+		*fields = append(*fields, name)
+	}
+}
+
+// skips reports whether sc leaves out the field at loc
+func (sc scope) skips(loc location) bool {
+	if sc.except[loc.path] || sc.except[loc.structPath] {
+		return true
+	}
+	return sc.only != nil && !sc.includes(loc.path) && !sc.includes(loc.structPath)
+}
+
+// includes reports whether the field at path is listed in sc.only,
+// leads to a listed field, or is held by one
+func (sc scope) includes(path string) bool {
+	if _, ok := sc.only[path]; ok {
+		return true
+	}
+	for i, r := range path {
+		if r == '.' && sc.only[path[:i]] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package v
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ladydascalie/v/validators"
+)
+
+const (
+	// separates the sections of a tag, such as `required;update:omitempty`
+	groupSeparator = ";"
+
+	// skips the rules of zero-valued fields
+	omitempty = "omitempty"
+)
+
+// plan is what the tags of a struct type compile to.
+// It is shared by every validation of the type, whatever its groups.
+type plan struct {
+	fields []fieldPlan
+	// err reports the first rule of the tags which is not known
+	err error
+}
+
+type fieldPlan struct {
+	index    int
+	field    reflect.StructField
+	jsonName string
+	sections []section
+}
+
+// section holds the rules of a tag which apply in group,
+// or in every group when it is empty.
+type section struct {
+	group string
+	rules []*node
+}
+
+// plans caches the plan of each type
+var plans sync.Map
+
+// compile returns the plan of struct type t, compiling it on first use
+func compile(t reflect.Type) *plan {
+	if p, ok := plans.Load(t); ok {
+		return p.(*plan)
+	}
+
+	p := &plan{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		// unexported fields are neither validated nor walked
+		if field.PkgPath != "" {
+			continue
+		}
+		sections := parseSections(fieldTag(t, field))
+		for _, s := range sections {
+			if err := checkRules(s.rules); err != nil && p.err == nil {
+				p.err = fmt.Errorf("invalid tag on %s.%s: %v", t.Name(), field.Name, err)
+			}
+		}
+		p.fields = append(p.fields, fieldPlan{
+			index:    i,
+			field:    field,
+			jsonName: jsonName(field),
+			sections: sections,
+		})
+	}
+
+	actual, _ := plans.LoadOrStore(t, p)
+	return actual.(*plan)
+}
+
+// rules returns the rules of the field applying in groups, in the order
+// of the tag, and whether they include omitempty, which is left out.
+func (fp fieldPlan) rules(groups []string) (rules []*node, omit bool) {
+	for _, s := range fp.sections {
+		if s.group != "" && !contains(groups, s.group) {
+			continue
+		}
+		for _, rule := range s.rules {
+			omit = omit || rule.has(omitempty)
+			if !rule.is(omitempty) {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return rules, omit
+}
+
+// GroupRules returns the rules of a `v` tag which apply when validating
+// the given groups: the rules outside of any group, followed in the order
+// of the tag by those of the groups. Aliases are expanded, the arguments of
+// rules unescaped, and compositions of rules are wrapped in parentheses or
+// start with "!", such as `(matches:uuid4 || empty_string)`.
+func GroupRules(tag string, groups ...string) []string {
+	var rules []string
+	for _, s := range parseSections(tag) {
+		if s.group == "" || contains(groups, s.group) {
+			for _, rule := range s.rules {
+				rules = append(rules, rule.terms()...)
+			}
+		}
+	}
+	return rules
+}
+
+// checkRules reports the first rule which could not be parsed, or which is
+// neither built in nor an alias, such as a misspelled one, or one scoped to
+// an undeclared group.
+func checkRules(rules []*node) error {
+	for _, rule := range rules {
+		if rule.kind == invalidNode {
+			return fmt.Errorf("cannot parse %s: %v (arguments holding \",\", \")\" or \"||\" must be quoted)", rule.args, rule.err)
+		}
+		if rule.kind == ruleNode && !isBuiltin(rule.name) {
+			if !isName(rule.name) {
+				return fmt.Errorf("unknown rule %s (arguments holding \",\", \")\" or \"||\" must be quoted)", rule.name)
+			}
+			return fmt.Errorf("unknown rule %s", rule.name)
+		}
+		if err := checkRules(rule.children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseSections splits a tag into its sections. A section is scoped to a
+// group when it starts with the name of a group declared by RegisterGroup,
+// followed by a colon: `create:required` is scoped to create, `maxchar:10`
+// is not.
+func parseSections(tag string) []section {
+	var sections []section
+	for _, raw := range splitSections(tag) {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		var s section
+		if i := strings.Index(raw, ":"); i > 0 {
+			if head := raw[:i]; isGroup(head) {
+				s.group, raw = head, raw[i+1:]
+			}
+		}
+		s.rules = parseRules(raw)
+		sections = append(sections, s)
+	}
+	return sections
+}
+
+// isRule reports whether name is that of a rule or alias, rather than of a group
+func isRule(name string) bool {
+	if isBuiltin(name) {
+		return true
+	}
+	_, ok := getAlias(name)
+	return ok
+}
+
+// isBuiltin reports whether name is that of a rule
+func isBuiltin(name string) bool {
+	if name == "func" || name == omitempty {
+		return true
+	}
+	_, ok := validators.FuncMap[name]
+	return ok
+}
+
+// isName reports whether s may be the name of a rule, rather than what is
+// left of arguments cut short by an unquoted ","
+func isName(s string) bool {
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
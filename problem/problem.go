@@ -0,0 +1,127 @@
+// Package problem renders validation failures as RFC 7807
+// `application/problem+json` documents.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ladydascalie/v"
+	"github.com/ladydascalie/v/validators"
+)
+
+// ContentType is the media type of problem documents
+const ContentType = "application/problem+json"
+
+// Type is the URI identifying validation problems in documents.
+// Set it to a page documenting your API's validation errors.
+var Type = "about:blank"
+
+// Document is an RFC 7807 problem details document
+type Document struct {
+	Type   string  `json:"type"`
+	Title  string  `json:"title"`
+	Status int     `json:"status"`
+	Detail string  `json:"detail,omitempty"`
+	Errors []Error `json:"errors,omitempty"`
+}
+
+// Error describes the failure of a single rule
+type Error struct {
+	// Pointer is the JSON Pointer (RFC 6901) to the invalid member
+	Pointer string            `json:"pointer"`
+	Code    string            `json:"code,omitempty"`
+	Message string            `json:"message"`
+	Params  validators.Params `json:"params,omitempty"`
+}
+
+// New builds the document describing err. Validation errors result in
+// a 422 Unprocessable Entity document listing each failure, any other
+// error in a 400 Bad Request document detailing it.
+func New(err error) *Document {
+	items := collect(err)
+	if len(items) == 0 {
+		doc := &Document{
+			Type:   Type,
+			Title:  http.StatusText(http.StatusBadRequest),
+			Status: http.StatusBadRequest,
+		}
+		if err != nil {
+			doc.Detail = err.Error()
+		}
+		return doc
+	}
+	return &Document{
+		Type:   Type,
+		Title:  http.StatusText(http.StatusUnprocessableEntity),
+		Status: http.StatusUnprocessableEntity,
+		Errors: items,
+	}
+}
+
+// Write writes the document describing err to w
+func Write(w http.ResponseWriter, err error) error {
+	return New(err).Write(w)
+}
+
+// Write writes the document to w, using its status as the response's
+func (d *Document) Write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(d.Status)
+	return json.NewEncoder(w).Encode(d)
+}
+
+// Handler returns an http.Handler writing the document describing err
+func Handler(err error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		Write(w, err)
+	})
+}
+
+// collect flattens err into the list of failures it holds
+func collect(err error) []Error {
+	switch e := err.(type) {
+	case v.Errors:
+		var items []Error
+		for _, err := range e {
+			items = append(items, collect(err)...)
+		}
+		return items
+	case v.ErrorRequired:
+		return []Error{{
+			Pointer: Pointer(e.Path),
+			Code:    e.Code(),
+			Message: e.Message(),
+		}}
+	case v.ErrorValidation:
+		item := Error{
+			Pointer: Pointer(e.Path),
+			Code:    e.Code(),
+			Message: e.Message(),
+		}
+		var verr validators.Error
+		if errors.As(e.Err, &verr) {
+			item.Params = verr.Params
+		}
+		return []Error{item}
+	default:
+		return nil
+	}
+}
+
+// Pointer converts a dotted path, as found on validation errors,
+// into a JSON Pointer.
+func Pointer(path string) string {
+	if path == "" {
+		return ""
+	}
+	var b strings.Builder
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	for _, segment := range strings.Split(path, ".") {
+		b.WriteByte('/')
+		b.WriteString(escaper.Replace(segment))
+	}
+	return b.String()
+}
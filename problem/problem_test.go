@@ -0,0 +1,95 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ladydascalie/v"
+)
+
+type address struct {
+	Street string `json:"street" v:"maxchar:5"`
+}
+
+type person struct {
+	Name *string  `json:"name" v:"required"`
+	Age  int      `json:"age,omitempty" v:"between:21..130"`
+	Home address  `json:"home"`
+	Role string   `json:"a/b" v:"in:admin|user"`
+	Tags []string `v:"maxchar:2"`
+}
+
+func TestWrite(t *testing.T) {
+	err := v.Struct(person{
+		Age:  16,
+		Home: address{Street: "Baker Street"},
+		Role: "root",
+		Tags: []string{"abc"},
+	})
+	if err == nil {
+		t.Fatal("expected validation to fail")
+	}
+
+	rec := httptest.NewRecorder()
+	Handler(err).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("content type = %s, want %s", ct, ContentType)
+	}
+
+	var doc Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Type != "about:blank" || doc.Title != "Unprocessable Entity" || doc.Status != 422 {
+		t.Errorf("unexpected document: %+v", doc)
+	}
+
+	want := []struct{ pointer, code string }{
+		{"/name", "required.missing"},
+		{"/age", "between.out_of_range"},
+		{"/home/street", "maxchar.too_long"},
+		{"/a~1b", "in.not_allowed"},
+		{"/Tags", "maxchar.too_long"},
+	}
+	if len(doc.Errors) != len(want) {
+		t.Fatalf("got %d errors, want %d: %+v", len(doc.Errors), len(want), doc.Errors)
+	}
+	for i, w := range want {
+		got := doc.Errors[i]
+		if got.Pointer != w.pointer || got.Code != w.code || got.Message == "" {
+			t.Errorf("errors[%d] = %+v, want pointer %s and code %s", i, got, w.pointer, w.code)
+		}
+	}
+	if min := doc.Errors[1].Params["min"]; min != 21.0 {
+		t.Errorf("expected the rule params to be kept, got %v", doc.Errors[1].Params)
+	}
+}
+
+func TestNew_otherErrors(t *testing.T) {
+	doc := New(errors.New("unexpected EOF"))
+	if doc.Status != http.StatusBadRequest || doc.Detail != "unexpected EOF" || len(doc.Errors) != 0 {
+		t.Errorf("unexpected document: %+v", doc)
+	}
+}
+
+func TestPointer(t *testing.T) {
+	tests := map[string]string{
+		"":          "",
+		"name":      "/name",
+		"home.zip":  "/home/zip",
+		"a/b.c~d":   "/a~1b/c~0d",
+		"deep.er.x": "/deep/er/x",
+	}
+	for path, want := range tests {
+		if got := Pointer(path); got != want {
+			t.Errorf("Pointer(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package v
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Rules maps the Go names of the fields of a struct onto their rules,
+// written as in the `v` tag, groups included.
+type Rules map[string]string
+
+// registered holds the rules registered for each type, by field name
+var registered = struct {
+	sync.RWMutex
+	rules map[reflect.Type]map[string]registeredRule
+}{rules: make(map[reflect.Type]map[string]registeredRule)}
+
+type registeredRule struct {
+	tag      string
+	override bool
+}
+
+// RegisterRules attaches rules to the fields of T, which may not be tagged,
+// such as generated or vendored types. They apply after those of the `v` tag.
+// Register them at init time, before validating any T:
+//
+//	v.RegisterRules[pb.User](v.Rules{"Email": "required,matches:email"})
+func RegisterRules[T any](rules Rules) error {
+	return registerRules(reflect.TypeOf((*T)(nil)).Elem(), rules, false)
+}
+
+// OverrideRules is like RegisterRules, the rules replacing
+// those of the `v` tag rather than being added to them.
+func OverrideRules[T any](rules Rules) error {
+	return registerRules(reflect.TypeOf((*T)(nil)).Elem(), rules, true)
+}
+
+func registerRules(t reflect.Type, rules Rules, override bool) error {
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("rules may only be registered for structs, got %s", t)
+	}
+	for name := range rules {
+		if field, ok := t.FieldByName(name); !ok || len(field.Index) != 1 {
+			return fmt.Errorf("%s has no field %s", t, name)
+		}
+	}
+
+	registered.Lock()
+	defer registered.Unlock()
+	fields := registered.rules[t]
+	if fields == nil {
+		fields = make(map[string]registeredRule)
+		registered.rules[t] = fields
+	}
+	for name, tag := range rules {
+		fields[name] = registeredRule{tag: tag, override: override}
+	}
+	// the type compiles again on its next validation
+	plans.Delete(t)
+	return nil
+}
+
+// fieldTag returns the rules of a field of t, from its
+// `v` tag and from those registered for t.
+func fieldTag(t reflect.Type, field reflect.StructField) string {
+	tag := field.Tag.Get(tagname)
+
+	registered.RLock()
+	rule, ok := registered.rules[t][field.Name]
+	registered.RUnlock()
+
+	switch {
+	case !ok:
+		return tag
+	case rule.override || tag == "":
+		return rule.tag
+	default:
+		return tag + groupSeparator + rule.tag
+	}
+}
@@ -0,0 +1,41 @@
+package v
+
+import (
+	"strings"
+	"testing"
+)
+
+type vendored struct {
+	Email string
+	Name  string `v:"maxchar:3"`
+	Role  string `v:"in:admin|user"`
+}
+
+func TestRegisterRules(t *testing.T) {
+	valid := vendored{Email: "jane@example.com", Name: "Jan", Role: "user"}
+	if err := Struct(vendored{Email: "nope", Name: "Jane", Role: "root"}); err == nil || len(err.(Errors)) != 2 {
+		t.Fatalf("expected the tags to apply, got %v", err)
+	}
+
+	if err := RegisterRules[vendored](Rules{"Email": "matches:email", "Name": "update:matches:alpha"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := OverrideRules[vendored](Rules{"Role": "in:root"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Struct(vendored{Email: "nope", Name: "Jane", Role: "root"})
+	if err == nil || len(err.(Errors)) != 2 || !strings.Contains(err.Error(), "Email") || !strings.Contains(err.Error(), "Name") {
+		t.Errorf("expected errors on Email and Name, got %v", err)
+	}
+	if err := StructGroups(vendored{Email: valid.Email, Name: "J4n", Role: "root"}, "update"); err == nil {
+		t.Error("expected the grouped rule to apply")
+	}
+
+	if err := RegisterRules[vendored](Rules{"Missing": "required"}); err == nil {
+		t.Error("expected an error for a missing field")
+	}
+	if err := RegisterRules[int](Rules{}); err == nil {
+		t.Error("expected an error for a non struct")
+	}
+}
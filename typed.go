@@ -0,0 +1,190 @@
+package v
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ladydascalie/v/validators"
+)
+
+// Names of common patterns, to be given to Matches
+const (
+	Email          = "email"
+	UUID           = "uuid"
+	URL            = "url"
+	Alpha          = "alpha"
+	Alphanumeric   = "alphanum"
+	Numeric        = "numeric"
+	PrintableASCII = "printable_ascii"
+)
+
+// Rule checks a value of type T, returning a validators.Error when it fails
+type Rule[T any] func(value T) error
+
+// Number is the set of types the numeric rules operate on
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Between checks that the value is within [min, max], as the between tag does
+func Between[T Number](min, max T) Rule[T] {
+	params := validators.Params{"min": float64(min), "max": float64(max)}
+	return func(value T) error {
+		if value < min || value > max {
+			return validators.NewError(validators.CodeBetweenOutOfRange, value, params, "expected a value between %v and %v, but got %v", min, max, value)
+		}
+		return nil
+	}
+}
+
+// In checks that the value is one of accepted, as the in tag does
+func In[T comparable](accepted ...T) Rule[T] {
+	names := make([]string, len(accepted))
+	for i, a := range accepted {
+		names[i] = fmt.Sprint(a)
+	}
+	params := validators.Params{"accepted": names}
+	return func(value T) error {
+		for _, a := range accepted {
+			if value == a {
+				return nil
+			}
+		}
+		return validators.NewError(validators.CodeInNotAllowed, value, params, "accepted values are: [%s], but got: %v", strings.Join(names, ", "), value)
+	}
+}
+
+// MaxChars checks that the value is at most max characters long, as the maxchar tag does
+func MaxChars(max int) Rule[string] {
+	return func(value string) error {
+		if count := utf8.RuneCountInString(value); count > max {
+			return validators.NewError(validators.CodeMaxcharTooLong, value, validators.Params{"max": max, "count": count}, "expected maximum %d characters, got: %d", max, count)
+		}
+		return nil
+	}
+}
+
+// Matches checks that the value matches the named pattern, as the matches tag
+// does, verifying the check digits of credit_card, isbn10 and isbn13 as well
+func Matches(name string) Rule[string] {
+	return func(value string) error {
+		return validators.Matches(name, value)
+	}
+}
+
+// Check binds rules to a field, see Field
+type Check struct {
+	ptr interface{}
+	run func() []error
+}
+
+// Field binds rules to the field ptr points to, to be checked by Validate
+func Field[T any](ptr *T, rules ...Rule[T]) Check {
+	return Check{
+		ptr: ptr,
+		run: func() []error {
+			var errs []error
+			for _, rule := range rules {
+				if err := rule(*ptr); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return errs
+		},
+	}
+}
+
+// String binds rules to the string field ptr points to, see Field
+func String(ptr *string, rules ...Rule[string]) Check {
+	return Field(ptr, rules...)
+}
+
+// Validate validates the struct ptr points to with its tags, as Struct
+// does, then runs the checks, which must bind fields of that struct:
+//
+//	err := v.Validate(&u,
+//		v.Field(&u.Age, v.Between(21, 120)),
+//		v.String(&u.Name, v.MaxChars(255), v.Matches(v.Email)),
+//	)
+//
+// Failed checks are reported as ErrorValidation, like failed tags.
+func Validate(ptr interface{}, checks ...Check) error {
+	return std.Validate(ptr, checks...)
+}
+
+// Validate validates the struct ptr points to, then runs the checks.
+// See the package level Validate.
+func (val *Validator) Validate(ptr interface{}, checks ...Check) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("only pointers to structs may be validated")
+	}
+	tr := val.translator(context.Background())
+
+	var vErrors validationErorrs
+	if err := validateStruct(ptr, location{}, val.scope()); err != nil {
+		var errs Errors
+		if !errors.As(err, &errs) {
+			return err
+		}
+		vErrors = append(vErrors, errs...)
+	}
+
+	fields := make(map[address]located)
+	locate(rv.Elem(), location{}, fields)
+	for _, check := range checks {
+		target := reflect.ValueOf(check.ptr)
+		f, ok := fields[address{target.Pointer(), target.Type().Elem()}]
+		if !ok {
+			return fmt.Errorf("cannot find the %s checked in %T", target.Type().Elem(), ptr)
+		}
+		for _, err := range check.run() {
+			vErrors = append(vErrors, ErrorValidation{
+				Name:       f.field.Name,
+				JSONName:   f.jsonName,
+				Path:       f.loc.path,
+				StructPath: f.loc.structPath,
+				Err:        err,
+				Label:      tr.label(f.field),
+				Locale:     tr.locale,
+				messages:   tr.messages,
+			})
+		}
+	}
+	return vErrors.Error()
+}
+
+// address identifies a field in memory. Its type tells apart
+// a struct field from the first of its own fields.
+type address struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type located struct {
+	field    reflect.StructField
+	jsonName string
+	loc      location
+}
+
+// locate maps the addresses of the fields of rv, and of its nested structs
+func locate(rv reflect.Value, loc location, fields map[address]located) {
+	for _, fp := range compile(rv.Type()).fields {
+		value := rv.Field(fp.index)
+		fieldLoc := loc.field(fp.field, fp.jsonName)
+		fields[address{value.Addr().Pointer(), value.Type()}] = located{fp.field, fp.jsonName, fieldLoc}
+
+		if value.Kind() == reflect.Ptr && !value.IsNil() {
+			value = value.Elem()
+		}
+		if value.Kind() == reflect.Struct {
+			locate(value, fieldLoc, fields)
+		}
+	}
+}
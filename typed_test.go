@@ -0,0 +1,82 @@
+package v
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ladydascalie/v/validators"
+)
+
+func TestValidate(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+	type user struct {
+		Name    string `json:"name" v:"maxchar:10"`
+		Age     int    `json:"age"`
+		Role    string
+		Address address `json:"address"`
+	}
+
+	u := user{Name: "not an email", Age: 12, Role: "root", Address: address{City: "Paris"}}
+	err := Validate(&u,
+		Field(&u.Age, Between(21, 120)),
+		String(&u.Name, MaxChars(255), Matches(Email)),
+		Field(&u.Role, In("admin", "user")),
+		String(&u.Address.City, MaxChars(3)),
+	)
+
+	var got [][2]string
+	for _, err := range err.(Errors) {
+		var verr ErrorValidation
+		if !errors.As(err, &verr) {
+			t.Fatalf("expected an ErrorValidation, got %T", err)
+		}
+		got = append(got, [2]string{verr.Path, verr.Code()})
+	}
+	want := [][2]string{
+		{"name", validators.CodeMaxcharTooLong},
+		{"age", validators.CodeBetweenOutOfRange},
+		{"name", validators.CodeMatchesNoMatch},
+		{"Role", validators.CodeInNotAllowed},
+		{"address.city", validators.CodeMaxcharTooLong},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("errors = %v, want %v", got, want)
+	}
+
+	u = user{Name: "jane@a.io", Age: 30, Role: "admin", Address: address{City: "Rio"}}
+	if err := Validate(&u, Field(&u.Age, Between(21, 120)), String(&u.Name, Matches(Email))); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var other int
+	if err := Validate(&u, Field(&other, Between(1, 2))); err == nil {
+		t.Error("expected an error for a field outside of the struct")
+	}
+	if err := Validate(u); err == nil {
+		t.Error("expected an error when not passed a pointer")
+	}
+}
+
+func TestRules(t *testing.T) {
+	if err := Between(1.5, 2.5)(2); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Between[uint8](1, 2)(3); err == nil {
+		t.Error("expected an error out of range")
+	}
+	if err := MaxChars(3)("日本語"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Matches("unknown")("x"); err.(validators.Error).Code != validators.CodeMatchesUnknownPattern {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Matches("credit_card")("4111111111111111"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Matches("credit_card")("4111111111111112"); err == nil || err.(validators.Error).Code != validators.CodeMatchesNoMatch {
+		t.Errorf("expected an invalid check digit to be rejected, got %v", err)
+	}
+}
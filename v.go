@@ -1,17 +1,20 @@
 package v
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"strings"
 
+	"github.com/ladydascalie/v/modifiers"
 	"github.com/ladydascalie/v/validators"
 )
 
 const (
-	tagname = "v"
-	jsontag = "json"
+	tagname  = "v"
+	jsontag  = "json"
+	labeltag = "label"
 
 	// common tags
 	required = "required"
@@ -29,9 +32,69 @@ func Get(tag string) (validator validators.Validator, ok bool) {
 	return c.Get(tag)
 }
 
+// RegisterPattern registers a named pattern for the matches rule,
+// such as `matches:slug` once registered as "slug". See validators.RegisterPattern.
+func RegisterPattern(name, pattern string) error {
+	return validators.RegisterPattern(name, pattern)
+}
+
+// SetModifier sets a new modifier into the custom modifiers func map
+func SetModifier(tag string, modifier modifiers.Modifier) {
+	c := modifiers.GetFuncMap()
+	c.Set(tag, modifier)
+}
+
+// GetModifier gets a modifier from the custom modifiers func map
+func GetModifier(tag string) (modifier modifiers.Modifier, ok bool) {
+	c := modifiers.GetFuncMap()
+	return c.Get(tag)
+}
+
 // Struct takes in an interface, which must be a struct
 // all validation is ran based on the provided tags.
+// The errors of every failing field are returned, as Errors.
 func Struct(structure interface{}) error {
+	return std.Struct(structure)
+}
+
+// StructContext is like Struct, rendering error messages
+// in the locale set on ctx with WithLocale, if any.
+func StructContext(ctx context.Context, structure interface{}) error {
+	return std.StructContext(ctx, structure)
+}
+
+// StructGroups is like Struct, also applying the rules scoped to the given
+// groups, such as `v:"create:forbidden;update:required"`, which must be
+// declared with RegisterGroup. Rules outside of any group always apply.
+func StructGroups(structure interface{}, groups ...string) error {
+	return std.StructGroups(structure, groups...)
+}
+
+// Clean fills the zero fields of the struct ptr points to from their
+// `default` tags, applies their `mod` tags, then validates it.
+// See the defaults and modifiers packages.
+func Clean(ptr interface{}) error {
+	return std.Clean(ptr)
+}
+
+// scope holds the settings of a validation
+type scope struct {
+	// tr renders the messages of errors
+	tr translator
+	// now is the clock of the rules relative to the current time
+	now validators.Clock
+	// groups are the groups whose rules apply
+	groups []string
+	// only, when set, holds the paths of the fields to validate
+	only map[string]bool
+	// except holds the paths of the fields not to validate
+	except map[string]bool
+}
+
+// validateStruct runs the validation of a struct, producing errors
+// which render their messages with the translator of sc. loc locates
+// the struct from the one originally passed to Struct.
+func validateStruct(structure interface{}, loc location, sc scope) error {
 	// nothing to see here
 	if structure == nil {
 		return nil
@@ -43,124 +106,175 @@ func Struct(structure interface{}) error {
 		return errors.New("only structs may be passed to this method")
 	}
 
-	t := v.Type() // get the struct type
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i) // prepare the field
-		value := v.Field(i) // prepare the field value
+	// tags which cannot be compiled fail whatever the values
+	p := compile(v.Type())
+	if p.err != nil {
+		return p.err
+	}
+
+	// validation errors collection
+	var vErrors validationErorrs
+
+	for _, fp := range p.fields {
+		field := fp.field          // prepare the field
+		value := v.Field(fp.index) // prepare the field value
+
+		// locate the field
+		fieldLoc := loc.field(field, fp.jsonName)
+
+		// skip the fields left out, embedded structs being flattened
+		if fieldLoc != loc && sc.skips(fieldLoc) {
+			continue
+		}
 
 		// retrieve the underlying value if possible
 		if value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
 			value = value.Elem()
 		}
 
+		// get the rules of the groups being validated
+		vtags, omit := fp.rules(sc.groups)
+
+		// zero values are left alone when omitempty
+		if omit && (!value.IsValid() || value.IsZero()) {
+			continue
+		}
+
 		// recurse if this is an embedded struct
-		if value.Kind() == reflect.Struct && field.PkgPath == "" {
-			// only exported fields should do this
-			if err := Struct(value.Interface()); err != nil {
-				return err
+		if value.Kind() == reflect.Struct {
+			if err := validateStruct(value.Interface(), fieldLoc, sc); err != nil {
+				errs, ok := err.(Errors)
+				if !ok {
+					return err
+				}
+				vErrors = append(vErrors, errs...)
 			}
 		}
 
-		// get all the v tags
-		tags := field.Tag.Get(tagname)
-
-		// get the json tags
-		jtag := field.Tag.Get(jsontag)
-
-		// split the v tags on comma
-		vtags := strings.Split(tags, ",")
-
-		// validation errors collection
-		var vErrors validationErorrs
-
 		// range over the tags
 		for _, vtag := range vtags {
-			if err := handleValidationTag(vtag, jtag, field, value, structure); err != nil {
+			if err := handleValidationTag(vtag, fp.jsonName, fieldLoc, field, value, structure, sc); err != nil {
 				vErrors = append(vErrors, err)
 			}
 		}
-		if len(vErrors) != 0 {
-			return vErrors.Error()
-		}
 	}
-	return nil
+	return vErrors.Error()
+}
+
+// jsonName returns the name of the field in its json tag,
+// or an empty string if it has none or is not encoded.
+func jsonName(field reflect.StructField) string {
+	name := field.Tag.Get(jsontag)
+	if i := strings.Index(name, ","); i >= 0 {
+		name = name[:i]
+	}
+	if name == "-" && field.Tag.Get(jsontag) == "-" {
+		return ""
+	}
+	return name
+}
+
+// location locates a field from the validated structure
+type location struct {
+	// path is the dotted list of json names, or Go names when missing
+	path string
+	// structPath is the dotted list of Go names
+	structPath string
+}
+
+// field returns the location of a field of the struct at l.
+// Embedded structs are flattened, as encoding/json does.
+func (l location) field(field reflect.StructField, jsonName string) location {
+	if field.Anonymous && jsonName == "" {
+		return l
+	}
+	name := field.Name
+	if jsonName != "" {
+		name = jsonName
+	}
+	return location{
+		path:       joinPath(l.path, name),
+		structPath: joinPath(l.structPath, field.Name),
+	}
 }
 
-func handleValidationTag(vtag, jtag string, field reflect.StructField, value reflect.Value, structure interface{}) (err error) {
-	// sanitize the tag. when multiple tags are used
-	// some leading/trailing spaces may be left
-	vtag = strings.TrimSpace(vtag)
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
 
+func handleValidationTag(rule *node, jtag string, loc location, field reflect.StructField, value reflect.Value, structure interface{}, sc scope) (err error) {
 	// guard against unexported fields
-	// or simply missing or invalid tags
-	if field.PkgPath != "" || vtag == "" {
+	if field.PkgPath != "" {
 		return
 	}
 
 	// is the field required but invalid?
 	// this will trigger for instance on a *string
 	// which has not been initialized.
-	if !value.IsValid() && vtag == required {
+	if !value.IsValid() && rule.has(required) {
 		return ErrorRequired{
-			Field:    field.Name,
-			JSONName: jtag,
+			Field:      field.Name,
+			JSONName:   jtag,
+			Path:       loc.path,
+			StructPath: loc.structPath,
+			Label:      sc.tr.label(field),
+			Locale:     sc.tr.locale,
+			messages:   sc.tr.messages,
 		}
 	}
 	// Our field is valid, and we can interface without panic
 	// we are ready to send it to the validator methods
 	if value.IsValid() && value.CanInterface() {
-		if err = validate(vtag, value.Interface(), structure); err != nil {
+		if err = rule.run(value.Interface(), structure, sc.now); err != nil {
 			return ErrorValidation{
-				Name:     field.Name,
-				JSONName: jtag,
-				Err:      err,
+				Name:       field.Name,
+				JSONName:   jtag,
+				Path:       loc.path,
+				StructPath: loc.structPath,
+				Err:        err,
+				Label:      sc.tr.label(field),
+				Locale:     sc.tr.locale,
+				messages:   sc.tr.messages,
 			}
 		}
 	}
 	return
 }
 
+// validate validates value against the rules of a tag,
+// returning the first error
 func validate(tag string, value, structure interface{}) error {
-	vtag := newValidationTag(tag)
-	if vtag == nil {
-		return fmt.Errorf("v cannot parse struct tag <%v> please refer to the format rules", tag)
+	for _, rule := range parseRules(tag) {
+		if err := rule.run(value, structure, nil); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+// runRule validates value against the named rule. Rules relative to the
+// current time read now, when set.
+func runRule(name, args string, value, structure interface{}, now validators.Clock) error {
 	// first check for custom functions
-	if vtag.Name == "func" {
-		fn, ok := validators.CustomFuncMap.Get(vtag.Args)
+	if name == "func" {
+		fn, ok := validators.CustomFuncMap.Get(args)
 		if ok {
-			return fn(vtag.Args, value, structure)
+			return fn(args, value, structure)
 		}
-		return fmt.Errorf("custom validator %s did not match any available function", vtag.Args)
+		return fmt.Errorf("custom validator %s did not match any available function", args)
 	}
 
-	// run through the func map and see if there's a match
-	for name, method := range validators.FuncMap {
-		if vtag.Name == name {
-			return method(vtag.Args, value)
-		}
+	// rules relative to the current time read the clock
+	if method, ok := validators.ClockFuncMap[name]; ok && now != nil {
+		return method(now, args, value)
 	}
-	return fmt.Errorf("could not parse validation tag: %s", vtag.Name)
-}
-
-type validationTag struct {
-	Name string
-	Args string
-}
 
-func newValidationTag(tag string) *validationTag {
-	var vtag validationTag
-
-	parts := strings.SplitN(tag, ":", -1)
-	switch len(parts) {
-	case 1:
-		vtag.Name = parts[0]
-	case 2:
-		vtag.Name = parts[0]
-		vtag.Args = parts[1]
-	default:
-		return nil
+	// run through the func map and see if there's a match
+	if method, ok := validators.FuncMap[name]; ok {
+		return method(args, value)
 	}
-	return &vtag
+	return fmt.Errorf("could not parse validation tag: %s", name)
 }
@@ -1,12 +1,18 @@
 package v
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ladydascalie/v/catalog"
 	"github.com/ladydascalie/v/validators"
 )
 
@@ -15,6 +21,9 @@ func TestMain(m *testing.M) {
 	validators.CustomFuncMap.Set("custom_function", func(args string, value, structure interface{}) error {
 		return errors.New("custom_function was called")
 	})
+	if err := RegisterGroup("create", "update"); err != nil {
+		log.Fatal(err)
+	}
 	os.Exit(m.Run())
 }
 
@@ -217,3 +226,368 @@ func Test_validate(t *testing.T) {
 		})
 	}
 }
+
+func TestStruct_errorCodes(t *testing.T) {
+	err := Struct(struct {
+		Age  int    `v:"between:21..*" json:"age"`
+		Role string `v:"in:admin|user" json:"role"`
+	}{Age: 16, Role: "admin"})
+
+	var verr validators.Error
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a validators.Error, got %T: %v", err, err)
+	}
+	if verr.Code != validators.CodeBetweenOutOfRange {
+		t.Errorf("Code = %s, want %s", verr.Code, validators.CodeBetweenOutOfRange)
+	}
+	if verr.Value != 16 {
+		t.Errorf("Value = %v, want 16", verr.Value)
+	}
+
+	var ferr ErrorValidation
+	if !errors.As(err, &ferr) || ferr.Code() != validators.CodeBetweenOutOfRange {
+		t.Errorf("expected an ErrorValidation with code %s, got %v", validators.CodeBetweenOutOfRange, err)
+	}
+}
+
+func TestStructContext_locale(t *testing.T) {
+	type person struct {
+		Name *string `v:"required" json:"name"`
+		Age  int     `v:"between:21..*" json:"age"`
+	}
+
+	err := StructContext(WithLocale(context.Background(), "fr-FR"), person{Age: 16})
+	if want := "name : obligatoire, veuillez fournir une valeur | age : une valeur entre 21 et ∞ est attendue, reçu : 16"; err == nil || err.Error() != want {
+		t.Errorf("got %v, want %s", err, want)
+	}
+
+	name := "Jane"
+	err = New(Locale("de")).Struct(person{Name: &name, Age: 16})
+	if want := "age: ein Wert zwischen 21 und ∞ wird erwartet, erhalten: 16"; err == nil || err.Error() != want {
+		t.Errorf("got %v, want %s", err, want)
+	}
+
+	// unknown locales fall back on English
+	err = Localize(err, "xx")
+	if want := "[validation] age: expected a value between 21 and max float64, but got 16"; err == nil || err.Error() != want {
+		t.Errorf("got %v, want %s", err, want)
+	}
+}
+
+func TestStruct_labels(t *testing.T) {
+	type person struct {
+		FirstName string `v:"maxchar:3" json:"first_name" label:"First name"`
+		LastName  string `v:"maxchar:3" json:"last_name" label:"last_name"`
+	}
+	p := person{FirstName: "Jane", LastName: "Doe"}
+
+	err := Struct(p)
+	if want := "[validation] First name: expected maximum 3 characters, got: 4"; err == nil || err.Error() != want {
+		t.Errorf("got %v, want %s", err, want)
+	}
+	var verr ErrorValidation
+	if !errors.As(err, &verr) || verr.JSONName != "first_name" {
+		t.Errorf("expected the json name to be kept, got %#v", verr)
+	}
+
+	messages := catalog.New("en")
+	if err := messages.Add("fr", LabelKey("last_name"), "Nom"); err != nil {
+		t.Fatal(err)
+	}
+	if err := messages.Add("fr", catalog.FieldKey, "{{.Field}} : {{.Message}}"); err != nil {
+		t.Fatal(err)
+	}
+	err = New(Messages(messages), Locale("fr")).Struct(person{LastName: "Dupont"})
+	if want := "Nom : expected maximum 3 characters, got: 6"; err == nil || err.Error() != want {
+		t.Errorf("got %v, want %s", err, want)
+	}
+
+	upper := Labels(func(field reflect.StructField) string {
+		return strings.ToUpper(field.Name)
+	})
+	err = New(upper).Struct(person{LastName: "Dupont"})
+	if want := "[validation] LASTNAME: expected maximum 3 characters, got: 6"; err == nil || err.Error() != want {
+		t.Errorf("got %v, want %s", err, want)
+	}
+}
+
+func TestStruct_paths(t *testing.T) {
+	type Base struct {
+		ID string `v:"maxchar:1" json:"id"`
+	}
+	type inner struct {
+		Zip string `v:"maxchar:1" json:"zip,omitempty"`
+	}
+	type outer struct {
+		Base
+		Home  inner  `json:"home"`
+		Work  *inner `json:"-"`
+		Other string `v:"maxchar:1"`
+	}
+	err := Struct(outer{
+		Base:  Base{ID: "12"},
+		Home:  inner{Zip: "12"},
+		Work:  &inner{Zip: "12"},
+		Other: "12",
+	})
+
+	var paths []string
+	for _, err := range err.(Errors) {
+		paths = append(paths, err.(ErrorValidation).Path)
+	}
+	if got, want := strings.Join(paths, " "), "id home.zip Work.zip Other"; got != want {
+		t.Errorf("paths = %s, want %s", got, want)
+	}
+}
+
+func TestStruct_allFields(t *testing.T) {
+	type person struct {
+		Name string `v:"maxchar:3" json:"name"`
+		Role string `v:"in:admin|user" json:"role"`
+		Age  int    `v:"between:21..*" json:"age"`
+	}
+	err := Struct(person{Name: "Jane", Role: "admin", Age: 16})
+
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("expected Errors, got %T: %v", err, err)
+	}
+	var paths []string
+	for _, err := range errs {
+		paths = append(paths, err.(ErrorValidation).Path)
+	}
+	// the fields failing after the first one are reported too
+	if got, want := strings.Join(paths, " "), "name age"; got != want {
+		t.Errorf("paths = %s, want %s", got, want)
+	}
+}
+
+func TestClean(t *testing.T) {
+	SetModifier("strip_dashes", func(_ string, value string) string {
+		return strings.Replace(value, "-", "", -1)
+	})
+	if _, ok := GetModifier("strip_dashes"); !ok {
+		t.Fatal("expected the modifier to be registered")
+	}
+
+	type signup struct {
+		Email string `mod:"trim,lower" v:"maxchar:16,matches:email"`
+		Phone string `mod:"strip_dashes" v:"maxchar:10"`
+		Lang  string `default:" EN " mod:"trim,lower" v:"required"`
+	}
+	s := signup{Email: "  Jane@Example.COM ", Phone: "555-123-4567"}
+	if err := Clean(&s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Email != "jane@example.com" || s.Phone != "5551234567" || s.Lang != "en" {
+		t.Errorf("unexpected result: %+v", s)
+	}
+	if err := Clean(signup{}); err == nil {
+		t.Error("expected an error when not passed a pointer")
+	}
+}
+
+func TestClock(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	val := New(Clock(func() time.Time { return now }))
+
+	type booking struct {
+		Start time.Time     `v:"after:now,before:now+720h"`
+		End   *time.Time    `v:"omitempty,after:2026-01-01"`
+		Stay  time.Duration `v:"duration:1h..*"`
+	}
+	start := now.Add(24 * time.Hour)
+	if err := val.Struct(booking{Start: start, End: &start, Stay: 2 * time.Hour}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := val.Struct(booking{Start: now.Add(-time.Hour), Stay: time.Minute})
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", err)
+	}
+	for i, code := range []string{validators.CodeAfterNotAfter, validators.CodeDurationOutOfRange} {
+		if got := errs[i].(ErrorValidation).Code(); got != code {
+			t.Errorf("error %d: code = %s, want %s", i, got, code)
+		}
+	}
+
+	err = val.Values(url.Values{"from": {"2026-01-14"}}, map[string]string{"from": "after:now"})
+	if err == nil {
+		t.Error("expected the clock to apply to Values")
+	}
+}
+
+func TestStructGroups(t *testing.T) {
+	type user struct {
+		ID    *int   `v:"create:forbidden;update:required"`
+		Name  string `v:"create:required;update:omitempty,maxchar:5"`
+		Roles []string
+	}
+	id := 1
+	tests := []struct {
+		name   string
+		user   user
+		groups []string
+		want   []string
+	}{
+		{name: "no group", user: user{ID: &id, Name: "too long"}},
+		{name: "create", user: user{Name: "jane"}, groups: []string{"create"}},
+		{name: "create with id", user: user{ID: &id, Name: "jane"}, groups: []string{"create"}, want: []string{"forbidden.present"}},
+		{name: "update", user: user{ID: &id}, groups: []string{"update"}},
+		{name: "update without id", user: user{Name: "too long"}, groups: []string{"update"}, want: []string{"required.missing", "maxchar.too_long"}},
+		{name: "unknown group", user: user{}, groups: []string{"delete"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := StructGroups(tt.user, tt.groups...)
+			var codes []string
+			if err != nil {
+				for _, err := range err.(Errors) {
+					codes = append(codes, err.(interface{ Code() string }).Code())
+				}
+			}
+			if !reflect.DeepEqual(codes, tt.want) {
+				t.Errorf("codes = %v, want %v", codes, tt.want)
+			}
+		})
+	}
+}
+
+func TestStruct_unknownRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		structure interface{}
+	}{
+		{name: "misspelled rule", structure: struct {
+			Name string `v:"maxchr:3"`
+		}{Name: "Jane"}},
+		{name: "undeclared group", structure: struct {
+			Name string `v:"delete:forbidden"`
+		}{}},
+		{name: "nested", structure: struct {
+			Name string `v:"required,(maxchar:3 || emty_string)"`
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the tag fails, whatever the value
+			err := Struct(tt.structure)
+			if err == nil || !strings.Contains(err.Error(), "unknown rule") {
+				t.Errorf("expected an unknown rule error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRegisterGroup(t *testing.T) {
+	for _, name := range []string{"", "a:b", "with space", "maxchar"} {
+		if err := RegisterGroup(name); err == nil {
+			t.Errorf("expected an error registering %q", name)
+		}
+	}
+	if err := Alias("create", "required"); err == nil {
+		t.Error("expected an error for an alias shadowing a group")
+	}
+}
+
+func TestGroupRules(t *testing.T) {
+	tag := "required; create:forbidden; update:omitempty,maxchar:255"
+	if got, want := GroupRules(tag), []string{"required"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupRules() = %v, want %v", got, want)
+	}
+	if got, want := GroupRules(tag, "update"), []string{"required", "omitempty", "maxchar:255"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupRules(update) = %v, want %v", got, want)
+	}
+	if got, want := GroupRules("maxchar:10,in:a|b"), []string{"maxchar:10", "in:a|b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupRules() = %v, want %v", got, want)
+	}
+}
+
+func TestStructPartial(t *testing.T) {
+	type address struct {
+		City string `json:"city" v:"maxchar:5"`
+		Zip  *int   `json:"zip" v:"required"`
+	}
+	type patch struct {
+		Name    *string  `json:"name" v:"required"`
+		Email   *string  `json:"email" v:"required"`
+		Address *address `json:"address" v:"required"`
+	}
+	long := "too long"
+
+	tests := []struct {
+		name   string
+		body   string
+		patch  patch
+		fields []string
+		want   []string
+	}{
+		{name: "nothing sent", body: `{}`},
+		{name: "name sent", body: `{"name": "too long"}`, patch: patch{Name: &long}},
+		{name: "nested field sent", body: `{"address": {"city": "too long"}}`, patch: patch{Address: &address{City: long}}, want: []string{"address.city"}},
+		{name: "null sent", body: `{"email": null}`, want: []string{"email"}},
+		{name: "empty object sent", body: `{"address": {}}`, patch: patch{Address: &address{}}, want: []string{"address.zip"}},
+		{name: "empty object required", body: `{"address": {}}`, want: []string{"address"}},
+		{name: "go names", fields: []string{"Address.City"}, patch: patch{Address: &address{City: long}}, want: []string{"address.city"}},
+		{name: "parent listed", fields: []string{"address"}, patch: patch{Address: &address{City: long}}, want: []string{"address.city", "address.zip"}},
+		{name: "parent listed with a child", fields: []string{"address.city", "Address"}, patch: patch{Address: &address{City: "Paris"}}, want: []string{"address.zip"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := tt.fields
+			if tt.body != "" {
+				var err error
+				if fields, err = PresentFields([]byte(tt.body)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var paths []string
+			if err := StructPartial(tt.patch, fields...); err != nil {
+				for _, err := range err.(Errors) {
+					var verr ErrorValidation
+					var rerr ErrorRequired
+					switch {
+					case errors.As(err, &verr):
+						paths = append(paths, verr.Path)
+					case errors.As(err, &rerr):
+						paths = append(paths, rerr.Path)
+					}
+				}
+			}
+			if !reflect.DeepEqual(paths, tt.want) {
+				t.Errorf("paths = %v, want %v", paths, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructExcept(t *testing.T) {
+	type address struct {
+		Zip *int `v:"required"`
+	}
+	type user struct {
+		ID      *int `v:"required"`
+		Name    *int `v:"required"`
+		Address address
+	}
+	err := StructExcept(user{}, "ID", "Address")
+	if err == nil || len(err.(Errors)) != 1 || !strings.Contains(err.Error(), "Name") {
+		t.Errorf("expected a single error on Name, got %v", err)
+	}
+}
+
+func TestPresentFields(t *testing.T) {
+	fields, err := PresentFields([]byte(`{"b": 1, "a": {"c": [{"d": 1}], "e": {}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a.c", "a.e", "b"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("PresentFields() = %v, want %v", fields, want)
+	}
+	for _, body := range []string{`[]`, `null`, `{`} {
+		if _, err := PresentFields([]byte(body)); err == nil {
+			t.Errorf("expected an error for %s", body)
+		}
+	}
+}
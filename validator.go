@@ -0,0 +1,113 @@
+package v
+
+import (
+	"context"
+	"time"
+
+	"github.com/ladydascalie/v/catalog"
+	"github.com/ladydascalie/v/defaults"
+	"github.com/ladydascalie/v/modifiers"
+	"github.com/ladydascalie/v/validators"
+)
+
+// std is the Validator used by the package level functions
+var std = New()
+
+// Validator validates structures, rendering the messages
+// of its errors in the locale it was configured with.
+type Validator struct {
+	locale   string
+	messages *catalog.Catalog
+	labels   LabelFunc
+	clock    validators.Clock
+}
+
+// Option configures a Validator
+type Option func(*Validator)
+
+// Locale sets the locale error messages are rendered in, such as fr or de-CH.
+// English is used when no locale is set, and whenever a translation is missing.
+func Locale(locale string) Option {
+	return func(val *Validator) {
+		val.locale = locale
+	}
+}
+
+// Messages sets the catalog messages are translated with.
+// It defaults to catalog.Default.
+func Messages(messages *catalog.Catalog) Option {
+	return func(val *Validator) {
+		val.messages = messages
+	}
+}
+
+// Labels sets where the human-facing names of fields are read from.
+// It defaults to LabelTag.
+func Labels(labels LabelFunc) Option {
+	return func(val *Validator) {
+		val.labels = labels
+	}
+}
+
+// Clock sets the clock rules relative to the current time read, such as
+// `after:now`, so that they may be tested deterministically.
+// It defaults to time.Now.
+func Clock(now func() time.Time) Option {
+	return func(val *Validator) {
+		val.clock = now
+	}
+}
+
+// New returns a Validator configured with the given options
+func New(opts ...Option) *Validator {
+	val := &Validator{messages: catalog.Default, labels: LabelTag}
+	for _, opt := range opts {
+		opt(val)
+	}
+	return val
+}
+
+// Struct takes in an interface, which must be a struct
+// all validation is ran based on the provided tags.
+func (val *Validator) Struct(structure interface{}) error {
+	return validateStruct(structure, location{}, val.scope())
+}
+
+// StructContext is like Struct, rendering error messages in the locale
+// set on ctx with WithLocale, which takes precedence over the Validator's.
+func (val *Validator) StructContext(ctx context.Context, structure interface{}) error {
+	return validateStruct(structure, location{}, scope{tr: val.translator(ctx), now: val.clock})
+}
+
+// StructGroups is like Struct, also applying the rules scoped to the given groups.
+func (val *Validator) StructGroups(structure interface{}, groups ...string) error {
+	sc := val.scope()
+	sc.groups = groups
+	return validateStruct(structure, location{}, sc)
+}
+
+// Clean fills the zero fields of the struct ptr points to from their
+// `default` tags, applies their `mod` tags, then validates it.
+// See the defaults and modifiers packages.
+func (val *Validator) Clean(ptr interface{}) error {
+	if err := defaults.Set(ptr); err != nil {
+		return err
+	}
+	if err := modifiers.Struct(ptr); err != nil {
+		return err
+	}
+	return val.Struct(ptr)
+}
+
+// scope returns the scope of a validation of every field, in every group
+func (val *Validator) scope() scope {
+	return scope{tr: val.translator(context.Background()), now: val.clock}
+}
+
+func (val *Validator) translator(ctx context.Context) translator {
+	tr := translator{locale: val.locale, messages: val.messages, labels: val.labels}
+	if locale, ok := LocaleFrom(ctx); ok {
+		tr.locale = locale
+	}
+	return tr
+}
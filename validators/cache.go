@@ -0,0 +1,69 @@
+package validators
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+)
+
+// regexCacheSize is how many compiled expressions the regex validator keeps
+const regexCacheSize = 256
+
+// regexCache is a least recently used cache of compiled expressions
+var regexCache = &lru{
+	size:    regexCacheSize,
+	entries: make(map[string]*list.Element),
+	order:   list.New(),
+}
+
+type lru struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	// order lists the entries, most recently used first
+	order *list.List
+}
+
+type lruEntry struct {
+	pattern string
+	exp     *regexp.Regexp
+}
+
+// compileRegex returns the compiled pattern, from the cache when possible
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if exp, ok := regexCache.get(pattern); ok {
+		return exp, nil
+	}
+	exp, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.add(pattern, exp)
+	return exp, nil
+}
+
+func (c *lru) get(pattern string) (*regexp.Regexp, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[pattern]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).exp, true
+}
+
+func (c *lru) add(pattern string, exp *regexp.Regexp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[pattern]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[pattern] = c.order.PushFront(&lruEntry{pattern: pattern, exp: exp})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).pattern)
+	}
+}
@@ -0,0 +1,282 @@
+package validators
+
+import (
+	"strconv"
+	"strings"
+)
+
+// cardBrands lists the prefixes and lengths of the numbers of
+// each card brand, in the order brands are detected.
+var cardBrands = []struct {
+	name     string
+	prefixes [][2]int
+	lengths  [2]int
+}{
+	{"amex", [][2]int{{34, 34}, {37, 37}}, [2]int{15, 15}},
+	{"visa", [][2]int{{4, 4}}, [2]int{13, 19}},
+	{"mastercard", [][2]int{{51, 55}, {2221, 2720}}, [2]int{16, 16}},
+	{"discover", [][2]int{{6011, 6011}, {644, 649}, {65, 65}, {622126, 622925}}, [2]int{16, 19}},
+	{"diners", [][2]int{{300, 305}, {3095, 3095}, {36, 36}, {38, 39}}, [2]int{14, 19}},
+	{"jcb", [][2]int{{3528, 3589}}, [2]int{16, 19}},
+	{"unionpay", [][2]int{{62, 62}}, [2]int{16, 19}},
+	{"maestro", [][2]int{{5018, 5018}, {5020, 5020}, {5038, 5038}, {5893, 5893}, {6304, 6304}, {6759, 6759}, {6761, 6763}}, [2]int{12, 19}},
+}
+
+// CardNumber checks that the value is a card number passing the Luhn
+// checksum. Spaces and hyphens are ignored. The brands accepted may be
+// given as arguments, such as `credit_card:visa|mastercard`, out of amex,
+// visa, mastercard, discover, diners, jcb, unionpay and maestro.
+func CardNumber(args string, value interface{}) error {
+	var allowed []string
+	if args != "" {
+		allowed = strings.Split(args, "|")
+		for _, name := range allowed {
+			if !isCardBrand(name) {
+				return NewError(CodeCreditCardInvalidArgs, value, Params{"brands": allowed}, "unknown card brand: %s", name)
+			}
+		}
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return NewError(CodeCreditCardInvalidType, value, nil, "expected a string, but got %T", value)
+	}
+	digits, ok := stripDigits(str, "- ")
+	if !ok || len(digits) < 12 || len(digits) > 19 {
+		return NewError(CodeCreditCardInvalidFormat, str, nil, "expected a card number of 12 to 19 digits")
+	}
+	if !luhn(digits) {
+		return NewError(CodeCreditCardInvalidChecksum, str, nil, "invalid card number checksum")
+	}
+
+	brand := cardBrand(digits)
+	if allowed != nil && !strIn(brand, allowed) {
+		params := Params{"brand": brand, "brands": allowed}
+		if brand == "" {
+			return NewError(CodeCreditCardBrandNotAllowed, str, params, "accepted card brands are: [%s], but got an unknown brand", strings.Join(allowed, ", "))
+		}
+		return NewError(CodeCreditCardBrandNotAllowed, str, params, "accepted card brands are: [%s], but got: %s", strings.Join(allowed, ", "), brand)
+	}
+	return nil
+}
+
+// CardBrand returns the brand of a card number, or an empty string if unknown
+func CardBrand(number string) string {
+	digits, ok := stripDigits(number, "- ")
+	if !ok {
+		return ""
+	}
+	return cardBrand(digits)
+}
+
+func cardBrand(digits string) string {
+	for _, brand := range cardBrands {
+		if len(digits) < brand.lengths[0] || len(digits) > brand.lengths[1] {
+			continue
+		}
+		for _, prefix := range brand.prefixes {
+			if hasPrefixIn(digits, prefix[0], prefix[1]) {
+				return brand.name
+			}
+		}
+	}
+	return ""
+}
+
+func isCardBrand(name string) bool {
+	for _, brand := range cardBrands {
+		if brand.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPrefixIn reports whether the leading digits of digits, as many as
+// there are in low, form a number in [low, high].
+func hasPrefixIn(digits string, low, high int) bool {
+	var n, width int
+	for l := low; l > 0; l /= 10 {
+		width++
+	}
+	if len(digits) < width {
+		return false
+	}
+	for _, c := range digits[:width] {
+		n = n*10 + int(c-'0')
+	}
+	return n >= low && n <= high
+}
+
+// ISBN checks that the value is an ISBN-10 or ISBN-13 with a valid check
+// digit. Hyphens and spaces are ignored. Either form may be required with
+// `isbn:10` or `isbn:13`.
+func ISBN(args string, value interface{}) error {
+	if args != "" && args != "10" && args != "13" {
+		return NewError(CodeISBNInvalidArgs, value, Params{"version": args}, "isbn accepts 10 or 13 as a parameter, got: %s", args)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return NewError(CodeISBNInvalidType, value, nil, "expected a string, but got %T", value)
+	}
+
+	isbn := strings.NewReplacer("-", "", " ", "").Replace(str)
+	switch {
+	case len(isbn) == 10 && args != "13":
+		digits, ok := stripDigits(isbn[:9], "")
+		last := isbn[9]
+		if !ok || !(last >= '0' && last <= '9' || last == 'X' || last == 'x') {
+			break
+		}
+		var sum int
+		for i, c := range digits {
+			sum += (10 - i) * int(c-'0')
+		}
+		if last == 'X' || last == 'x' {
+			sum += 10
+		} else {
+			sum += int(last - '0')
+		}
+		if sum%11 != 0 {
+			return NewError(CodeISBNInvalidChecksum, str, nil, "invalid ISBN-10 check digit")
+		}
+		return nil
+	case len(isbn) == 13 && args != "10":
+		digits, ok := stripDigits(isbn, "")
+		if !ok || !(strings.HasPrefix(digits, "978") || strings.HasPrefix(digits, "979")) {
+			break
+		}
+		if !gs1(digits) {
+			return NewError(CodeISBNInvalidChecksum, str, nil, "invalid ISBN-13 check digit")
+		}
+		return nil
+	}
+
+	if args != "" {
+		return NewError(CodeISBNInvalidFormat, str, Params{"version": args}, "expected an ISBN-%s", args)
+	}
+	return NewError(CodeISBNInvalidFormat, str, nil, "expected an ISBN-10 or ISBN-13")
+}
+
+// GTIN checks that the value is a GS1 trade item number with a valid check
+// digit: an EAN-8, UPC-A, EAN-13 or GTIN-14. The lengths accepted may be
+// given as arguments: `gtin:13|8` for EANs, `gtin:12` for UPCs.
+func GTIN(args string, value interface{}) error {
+	lengths := []string{"8", "12", "13", "14"}
+	if args != "" {
+		lengths = strings.Split(args, "|")
+		for _, l := range lengths {
+			if !strIn(l, []string{"8", "12", "13", "14"}) {
+				return NewError(CodeGTINInvalidArgs, value, Params{"lengths": lengths}, "gtin accepts lengths of 8, 12, 13 or 14, got: %s", l)
+			}
+		}
+	}
+	str, ok := value.(string)
+	if !ok {
+		return NewError(CodeGTINInvalidType, value, nil, "expected a string, but got %T", value)
+	}
+
+	digits, ok := stripDigits(str, "")
+	if !ok || !strIn(strconv.Itoa(len(digits)), lengths) {
+		return NewError(CodeGTINInvalidFormat, str, Params{"lengths": lengths}, "expected a number of %s digits", strings.Join(lengths, ", "))
+	}
+	if !gs1(digits) {
+		return NewError(CodeGTINInvalidChecksum, str, nil, "invalid GTIN check digit")
+	}
+	return nil
+}
+
+// ISSN checks that the value is an ISSN, such as 0317-8471, with a valid check digit
+func ISSN(_ string, value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return NewError(CodeISSNInvalidType, value, nil, "expected a string, but got %T", value)
+	}
+
+	issn := strings.Replace(str, "-", "", 1)
+	if len(issn) != 8 {
+		return NewError(CodeISSNInvalidFormat, str, nil, "expected an ISSN of 8 characters")
+	}
+	digits, ok := stripDigits(issn[:7], "")
+	last := issn[7]
+	if !ok || !(last >= '0' && last <= '9' || last == 'X' || last == 'x') {
+		return NewError(CodeISSNInvalidFormat, str, nil, "expected an ISSN of 8 characters")
+	}
+
+	var sum int
+	for i, c := range digits {
+		sum += (8 - i) * int(c-'0')
+	}
+	check := byte('0' + (11-sum%11)%11)
+	if check == '0'+10 {
+		check = 'X'
+	}
+	if last == 'x' {
+		last = 'X'
+	}
+	if last != check {
+		return NewError(CodeISSNInvalidChecksum, str, nil, "invalid ISSN check digit")
+	}
+	return nil
+}
+
+// IMEI checks that the value is an IMEI of 15 digits passing the Luhn
+// checksum. Spaces and hyphens are ignored.
+func IMEI(_ string, value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return NewError(CodeIMEIInvalidType, value, nil, "expected a string, but got %T", value)
+	}
+	digits, ok := stripDigits(str, "- ")
+	if !ok || len(digits) != 15 {
+		return NewError(CodeIMEIInvalidFormat, str, nil, "expected an IMEI of 15 digits")
+	}
+	if !luhn(digits) {
+		return NewError(CodeIMEIInvalidChecksum, str, nil, "invalid IMEI check digit")
+	}
+	return nil
+}
+
+// stripDigits removes the characters in ignored from s,
+// and reports whether only digits are left.
+func stripDigits(s, ignored string) (string, bool) {
+	var b strings.Builder
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			b.WriteRune(c)
+		case strings.ContainsRune(ignored, c):
+		default:
+			return "", false
+		}
+	}
+	return b.String(), b.Len() > 0
+}
+
+// luhn reports whether digits pass the Luhn checksum
+func luhn(digits string) bool {
+	var sum int
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 0 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// gs1 reports whether digits pass the GS1 check used by GTINs and ISBN-13s
+func gs1(digits string) bool {
+	var sum int
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
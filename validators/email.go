@@ -0,0 +1,219 @@
+package validators
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+// The limits of RFC 5321, in bytes
+const (
+	maxLocalPart = 64
+	maxAddress   = 254
+)
+
+// DefaultMXTimeout bounds the DNS lookups of the `email:mx` rule,
+// unless EmailOptions set another timeout
+const DefaultMXTimeout = 5 * time.Second
+
+// MXResolver looks up the mail exchangers of a domain.
+// It is implemented by *net.Resolver, and may be stubbed in tests.
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// EmailOptions configures the `email` rule returned by EmailRule
+type EmailOptions struct {
+	// Resolver looks up the mail exchangers of the `email:mx` rule.
+	// It defaults to net.DefaultResolver.
+	Resolver MXResolver
+	// MXTimeout bounds the lookups of the `email:mx` rule.
+	// It defaults to DefaultMXTimeout.
+	MXTimeout time.Duration
+	// Disposable lists the domains rejected by the `email:no_disposable`
+	// rule. Their subdomains are rejected too.
+	Disposable []string
+}
+
+// defaultEmail is the rule of EmailAddress
+var defaultEmail = EmailRule(EmailOptions{})
+
+// EmailAddress checks that the value is an email address, as parsed by
+// net/mail, within the limits of RFC 5321: 64 bytes for the local part,
+// and 254 for the address. Internationalized domains are checked in their
+// punycode form, as converted by golang.org/x/net/idna. Options may be
+// given as arguments, such as `email:display_name|no_quoted`:
+//
+//   - display_name accepts addresses such as `Someone <someone@example.com>`
+//   - no_quoted rejects quoted local parts, such as `"some one"@example.com`
+//   - no_disposable rejects the disposable domains of EmailOptions
+//   - mx requires the domain to have mail exchangers, looked up by the MXResolver of EmailOptions
+//
+// EmailAddress knows no disposable domains, and looks up mail exchangers
+// with net.DefaultResolver. See EmailRule to configure them.
+func EmailAddress(args string, value interface{}) error {
+	return defaultEmail(args, value)
+}
+
+// EmailRule returns the `email` rule, configured with opts. See EmailAddress.
+// Register it in place of the built-in rule at init time:
+//
+//	validators.FuncMap["email"] = validators.EmailRule(validators.EmailOptions{Disposable: domains})
+func EmailRule(opts EmailOptions) BuiltInValidator {
+	if opts.Resolver == nil {
+		opts.Resolver = net.DefaultResolver
+	}
+	if opts.MXTimeout <= 0 {
+		opts.MXTimeout = DefaultMXTimeout
+	}
+	disposable := make(map[string]bool, len(opts.Disposable))
+	for _, domain := range opts.Disposable {
+		domain = strings.TrimSuffix(strings.TrimSpace(domain), ".")
+		if ascii, ok := domainToASCII(domain); ok {
+			domain = ascii
+		}
+		disposable[strings.ToLower(domain)] = true
+	}
+	return func(args string, value interface{}) error {
+		return checkEmail(opts, disposable, args, value)
+	}
+}
+
+// LoadDisposableDomains reads the domains listed in a file, one per line,
+// for EmailOptions.Disposable. Blank lines and lines starting with # are ignored.
+func LoadDisposableDomains(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var domains []string
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		domain := strings.TrimSpace(scanner.Text())
+		if domain == "" || strings.HasPrefix(domain, "#") {
+			continue
+		}
+		if ascii, ok := domainToASCII(strings.TrimSuffix(domain, ".")); !ok || !isHostname(ascii, 2) {
+			return nil, fmt.Errorf("%s:%d: invalid domain %q", path, line, domain)
+		}
+		domains = append(domains, domain)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+func checkEmail(opts EmailOptions, disposable map[string]bool, args string, value interface{}) error {
+	options := make(map[string]bool)
+	if args != "" {
+		for _, option := range strings.Split(args, "|") {
+			if !strIn(option, []string{"display_name", "no_quoted", "no_disposable", "mx"}) {
+				return NewError(CodeEmailInvalidArgs, value, Params{"option": option}, "unknown email option: %s", option)
+			}
+			options[option] = true
+		}
+	}
+
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeEmailInvalidType, value, nil, "expected a string or []byte, but got %T", value)
+	}
+	addr, err := mail.ParseAddress(str)
+	if err != nil {
+		return NewError(CodeEmailInvalid, value, nil, "expected an email address: %v", err)
+	}
+	if !options["display_name"] && (addr.Name != "" || strings.HasSuffix(strings.TrimSpace(str), ">")) {
+		return NewError(CodeEmailDisplayName, value, nil, "expected an email address without a display name")
+	}
+
+	at := strings.LastIndexByte(addr.Address, '@')
+	local, domain := addr.Address[:at], addr.Address[at+1:]
+	// net/mail unquotes local parts, such that "john"@example.com is read as
+	// john@example.com: quotes are looked for in the address as written
+	if options["no_quoted"] && (isQuoted(str) || !isDotAtom(local)) {
+		return NewError(CodeEmailQuotedLocalPart, value, nil, "expected an email address without a quoted local part")
+	}
+
+	ascii, ok := domainToASCII(domain)
+	if !ok || !isHostname(ascii, 2) {
+		return NewError(CodeEmailInvalidDomain, value, Params{"domain": domain}, "invalid email domain: %s", domain)
+	}
+	if len(local) > maxLocalPart {
+		return NewError(CodeEmailTooLong, value, Params{"part": "local", "max": maxLocalPart}, "expected a local part of at most %d bytes", maxLocalPart)
+	}
+	if len(local)+1+len(ascii) > maxAddress {
+		return NewError(CodeEmailTooLong, value, Params{"part": "address", "max": maxAddress}, "expected an email address of at most %d bytes", maxAddress)
+	}
+
+	if options["no_disposable"] {
+		for d := ascii; strings.Contains(d, "."); d = d[strings.IndexByte(d, '.')+1:] {
+			if disposable[d] {
+				return NewError(CodeEmailDisposable, value, Params{"domain": domain}, "disposable email domain: %s", domain)
+			}
+		}
+	}
+	if options["mx"] {
+		ctx, cancel := context.WithTimeout(context.Background(), opts.MXTimeout)
+		defer cancel()
+		mxs, err := opts.Resolver.LookupMX(ctx, ascii)
+		// a single "." host is a null MX, as of RFC 7505
+		if err == nil && (len(mxs) == 0 || len(mxs) == 1 && mxs[0].Host == ".") {
+			err = fmt.Errorf("%s accepts no mail", domain)
+		}
+		if err != nil {
+			return NewError(CodeEmailNoMX, value, Params{"domain": domain}, "no mail exchanger found for %s: %v", domain, err)
+		}
+	}
+	return nil
+}
+
+// domainToASCII converts an internationalized domain name to its lower
+// case ASCII form: bücher.example becomes xn--bcher-kva.example. Labels
+// are made of letters, marks, digits and hyphens, such that symbols, as
+// in 💩.com, are rejected.
+func domainToASCII(domain string) (string, bool) {
+	for _, c := range domain {
+		if c >= 0x80 && !unicode.In(c, unicode.L, unicode.M, unicode.Nd) {
+			return "", false
+		}
+	}
+	ascii, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", false
+	}
+	return strings.ToLower(ascii), true
+}
+
+// isQuoted reports whether the local part of the address str, as written, is quoted.
+// The address is read between angle brackets when given with a display name.
+func isQuoted(str string) bool {
+	str = strings.TrimSpace(str)
+	if i := strings.LastIndexByte(str, '<'); i >= 0 && strings.HasSuffix(str, ">") {
+		str = strings.TrimSpace(str[i+1 : len(str)-1])
+	}
+	return strings.HasPrefix(str, `"`)
+}
+
+// isDotAtom reports whether local may be written unquoted, as a dot-atom of RFC 5322
+func isDotAtom(local string) bool {
+	if local == "" || local[0] == '.' || local[len(local)-1] == '.' || strings.Contains(local, "..") {
+		return false
+	}
+	for _, c := range local {
+		if c < 0x80 && !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune(".!#$%&'*+-/=?^_`{|}~", c)) {
+			return false
+		}
+	}
+	return true
+}
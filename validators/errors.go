@@ -0,0 +1,236 @@
+package validators
+
+import "fmt"
+
+// Codes identifying each failure of the built-in validators.
+// They are stable, and meant to be matched against by clients.
+const (
+	CodeRequired = "required.missing"
+
+	CodeForbiddenPresent = "forbidden.present"
+
+	// reported for compositions of rules, such as `matches:uuid4 || empty_string` or `!in:root`
+	CodeOrNoMatch  = "or.no_match"
+	CodeNotMatched = "not.matched"
+
+	CodeMaxcharTooLong     = "maxchar.too_long"
+	CodeMaxcharInvalidArgs = "maxchar.invalid_args"
+	CodeMaxcharInvalidType = "maxchar.invalid_type"
+
+	CodeInNotAllowed  = "in.not_allowed"
+	CodeInInvalidArgs = "in.invalid_args"
+	CodeInInvalidType = "in.invalid_type"
+
+	CodeBetweenOutOfRange  = "between.out_of_range"
+	CodeBetweenInvalidArgs = "between.invalid_args"
+	CodeBetweenInvalidType = "between.invalid_type"
+
+	CodeBytesBetweenOutOfRange  = "bytes_between.out_of_range"
+	CodeBytesBetweenInvalidArgs = "bytes_between.invalid_args"
+	CodeBytesBetweenInvalidType = "bytes_between.invalid_type"
+
+	CodeEmptyStringNotEmpty    = "empty_string.not_empty"
+	CodeEmptyStringInvalidType = "empty_string.invalid_type"
+
+	CodeIsInt64Invalid     = "is_int64.invalid"
+	CodeIsInt64InvalidType = "is_int64.invalid_type"
+
+	CodeIsFloat64Invalid     = "is_float64.invalid"
+	CodeIsFloat64InvalidType = "is_float64.invalid_type"
+
+	CodeMatchesNoMatch        = "matches.no_match"
+	CodeMatchesUnknownPattern = "matches.unknown_pattern"
+	CodeMatchesInvalidType    = "matches.invalid_type"
+
+	CodeCreditCardInvalidFormat   = "credit_card.invalid_format"
+	CodeCreditCardInvalidChecksum = "credit_card.invalid_checksum"
+	CodeCreditCardBrandNotAllowed = "credit_card.brand_not_allowed"
+	CodeCreditCardInvalidArgs     = "credit_card.invalid_args"
+	CodeCreditCardInvalidType     = "credit_card.invalid_type"
+
+	CodeISBNInvalidFormat   = "isbn.invalid_format"
+	CodeISBNInvalidChecksum = "isbn.invalid_checksum"
+	CodeISBNInvalidArgs     = "isbn.invalid_args"
+	CodeISBNInvalidType     = "isbn.invalid_type"
+
+	CodeGTINInvalidFormat   = "gtin.invalid_format"
+	CodeGTINInvalidChecksum = "gtin.invalid_checksum"
+	CodeGTINInvalidArgs     = "gtin.invalid_args"
+	CodeGTINInvalidType     = "gtin.invalid_type"
+
+	CodeISSNInvalidFormat   = "issn.invalid_format"
+	CodeISSNInvalidChecksum = "issn.invalid_checksum"
+	CodeISSNInvalidType     = "issn.invalid_type"
+
+	CodeIMEIInvalidFormat   = "imei.invalid_format"
+	CodeIMEIInvalidChecksum = "imei.invalid_checksum"
+	CodeIMEIInvalidType     = "imei.invalid_type"
+
+	CodeIBANInvalidFormat     = "iban.invalid_format"
+	CodeIBANUnknownCountry    = "iban.unknown_country"
+	CodeIBANCountryNotAllowed = "iban.country_not_allowed"
+	CodeIBANInvalidLength     = "iban.invalid_length"
+	CodeIBANInvalidChecksum   = "iban.invalid_checksum"
+	CodeIBANInvalidType       = "iban.invalid_type"
+
+	CodeBICInvalidFormat = "bic.invalid_format"
+	CodeBICInvalidType   = "bic.invalid_type"
+
+	CodeCurrencyUnknown     = "currency.unknown"
+	CodeCurrencyInvalidType = "currency.invalid_type"
+
+	CodeMoneyInvalidFormat   = "money.invalid_format"
+	CodeMoneyTooManyDecimals = "money.too_many_decimals"
+	CodeMoneyInvalidArgs     = "money.invalid_args"
+	CodeMoneyInvalidType     = "money.invalid_type"
+
+	CodeIPInvalid     = "ip.invalid"
+	CodeIPInvalidType = "ip.invalid_type"
+
+	CodeIPv4Invalid     = "ipv4.invalid"
+	CodeIPv4InvalidType = "ipv4.invalid_type"
+
+	CodeIPv6Invalid     = "ipv6.invalid"
+	CodeIPv6InvalidType = "ipv6.invalid_type"
+
+	CodeCIDRInvalid     = "cidr.invalid"
+	CodeCIDRInvalidType = "cidr.invalid_type"
+
+	CodeIPInNotInRange  = "ip_in.not_in_range"
+	CodeIPInInvalid     = "ip_in.invalid"
+	CodeIPInInvalidArgs = "ip_in.invalid_args"
+	CodeIPInInvalidType = "ip_in.invalid_type"
+
+	CodePublicIPNotPublic   = "public_ip.not_public"
+	CodePublicIPInvalid     = "public_ip.invalid"
+	CodePublicIPInvalidType = "public_ip.invalid_type"
+
+	CodePrivateIPNotPrivate  = "private_ip.not_private"
+	CodePrivateIPInvalid     = "private_ip.invalid"
+	CodePrivateIPInvalidType = "private_ip.invalid_type"
+
+	CodeMACInvalid     = "mac.invalid"
+	CodeMACInvalidType = "mac.invalid_type"
+
+	CodePortInvalid     = "port.invalid"
+	CodePortInvalidType = "port.invalid_type"
+
+	CodeHostPortInvalid     = "hostport.invalid"
+	CodeHostPortInvalidType = "hostport.invalid_type"
+
+	CodeFQDNInvalid     = "fqdn.invalid"
+	CodeFQDNInvalidType = "fqdn.invalid_type"
+
+	CodeURLNotAbsolute      = "url.not_absolute"
+	CodeURLSchemeNotAllowed = "url.scheme_not_allowed"
+	CodeURLInvalid          = "url.invalid"
+	CodeURLInvalidType      = "url.invalid_type"
+
+	CodeURLHostMissing     = "url_host.missing"
+	CodeURLHostNotAllowed  = "url_host.not_allowed"
+	CodeURLHostInvalid     = "url_host.invalid"
+	CodeURLHostInvalidType = "url_host.invalid_type"
+
+	CodeURLNoUserinfoPresent     = "url_no_userinfo.present"
+	CodeURLNoUserinfoInvalid     = "url_no_userinfo.invalid"
+	CodeURLNoUserinfoInvalidType = "url_no_userinfo.invalid_type"
+
+	CodeURLMaxTooLong     = "url_max.too_long"
+	CodeURLMaxInvalidArgs = "url_max.invalid_args"
+	CodeURLMaxInvalidType = "url_max.invalid_type"
+
+	CodeURLRelativeNotRelative = "url_relative.not_relative"
+	CodeURLRelativeInvalid     = "url_relative.invalid"
+	CodeURLRelativeInvalidType = "url_relative.invalid_type"
+
+	CodeEmailInvalid         = "email.invalid"
+	CodeEmailDisplayName     = "email.display_name"
+	CodeEmailQuotedLocalPart = "email.quoted_local_part"
+	CodeEmailInvalidDomain   = "email.invalid_domain"
+	CodeEmailTooLong         = "email.too_long"
+	CodeEmailDisposable      = "email.disposable"
+	CodeEmailNoMX            = "email.no_mx"
+	CodeEmailInvalidArgs     = "email.invalid_args"
+	CodeEmailInvalidType     = "email.invalid_type"
+
+	CodeDatetimeInvalid     = "datetime.invalid"
+	CodeDatetimeInvalidArgs = "datetime.invalid_args"
+	CodeDatetimeInvalidType = "datetime.invalid_type"
+
+	CodeRFC3339Invalid     = "rfc3339.invalid"
+	CodeRFC3339InvalidType = "rfc3339.invalid_type"
+
+	CodeBeforeNotBefore   = "before.not_before"
+	CodeBeforeInvalid     = "before.invalid"
+	CodeBeforeInvalidArgs = "before.invalid_args"
+	CodeBeforeInvalidType = "before.invalid_type"
+
+	CodeAfterNotAfter    = "after.not_after"
+	CodeAfterInvalid     = "after.invalid"
+	CodeAfterInvalidArgs = "after.invalid_args"
+	CodeAfterInvalidType = "after.invalid_type"
+
+	CodeDurationOutOfRange  = "duration.out_of_range"
+	CodeDurationInvalid     = "duration.invalid"
+	CodeDurationInvalidArgs = "duration.invalid_args"
+	CodeDurationInvalidType = "duration.invalid_type"
+
+	CodeTimezoneUnknown     = "timezone.unknown"
+	CodeTimezoneInvalidType = "timezone.invalid_type"
+
+	CodeBCP47Invalid     = "bcp47.invalid"
+	CodeBCP47InvalidType = "bcp47.invalid_type"
+
+	CodeCountryUnknown     = "country.unknown"
+	CodeCountryInvalidArgs = "country.invalid_args"
+	CodeCountryInvalidType = "country.invalid_type"
+
+	CodeSubdivisionUnknown           = "subdivision.unknown"
+	CodeSubdivisionCountryNotAllowed = "subdivision.country_not_allowed"
+	CodeSubdivisionInvalidArgs       = "subdivision.invalid_args"
+	CodeSubdivisionInvalidType       = "subdivision.invalid_type"
+
+	CodeE164Invalid     = "e164.invalid"
+	CodeE164InvalidType = "e164.invalid_type"
+
+	CodePhoneInvalid           = "phone.invalid"
+	CodePhoneCountryNotAllowed = "phone.country_not_allowed"
+	CodePhoneInvalidArgs       = "phone.invalid_args"
+	CodePhoneInvalidType       = "phone.invalid_type"
+
+	CodeRegexNoMatch     = "regex.no_match"
+	CodeRegexInvalidArgs = "regex.invalid_args"
+	CodeRegexInvalidType = "regex.invalid_type"
+)
+
+// Params holds the arguments a rule was given, such as its bounds
+// or the list of accepted values.
+type Params map[string]interface{}
+
+// Error is the type of error returned by the built-in validators
+type Error struct {
+	// Code identifies the failure, see the Code constants
+	Code string
+	// Params holds the rule arguments
+	Params Params
+	// Value is the offending value
+	Value interface{}
+
+	msg string
+}
+
+// Error satisfies the builtin Error interface
+func (e Error) Error() string {
+	return e.msg
+}
+
+// NewError builds an Error, its message being formatted from format and a.
+// Custom validators may use it to report their own codes.
+func NewError(code string, value interface{}, params Params, format string, a ...interface{}) error {
+	return Error{
+		Code:   code,
+		Params: params,
+		Value:  value,
+		msg:    fmt.Sprintf(format, a...),
+	}
+}
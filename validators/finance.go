@@ -0,0 +1,148 @@
+package validators
+
+import (
+	"encoding/json"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ibanLengths maps the countries using IBANs onto the length of their IBANs,
+// as listed by the SWIFT IBAN registry.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+	"DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+	"FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+	"GT": 28, "HN": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26,
+	"IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20,
+	"LU": 20, "LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20,
+	"MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24,
+	"SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25,
+	"SV": 28, "TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+	"YE": 30,
+}
+
+var (
+	ibanRegExp   = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]+$`)
+	bicRegExp    = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+	amountRegExp = regexp.MustCompile(`^[-+]?[0-9]+(\.[0-9]+)?$`)
+)
+
+// IBAN checks that the value is an IBAN of the length its country
+// expects, passing the mod-97 checksum. Spaces are ignored. The countries
+// accepted may be given as arguments, such as `iban:DE|FR`.
+func IBAN(args string, value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return NewError(CodeIBANInvalidType, value, nil, "expected a string, but got %T", value)
+	}
+
+	iban := strings.ToUpper(strings.Replace(str, " ", "", -1))
+	if !ibanRegExp.MatchString(iban) {
+		return NewError(CodeIBANInvalidFormat, str, nil, "expected an IBAN, such as DE89 3704 0044 0532 0130 00")
+	}
+	country := iban[:2]
+	length, ok := ibanLengths[country]
+	if !ok {
+		return NewError(CodeIBANUnknownCountry, str, Params{"country": country}, "%s does not use IBANs", country)
+	}
+	if args != "" {
+		if allowed := strings.Split(args, "|"); !strIn(country, allowed) {
+			return NewError(CodeIBANCountryNotAllowed, str, Params{"country": country, "countries": allowed}, "accepted countries are: [%s], but got: %s", strings.Join(allowed, ", "), country)
+		}
+	}
+	if len(iban) != length {
+		return NewError(CodeIBANInvalidLength, str, Params{"country": country, "length": length}, "expected %d characters for an IBAN from %s, but got %d", length, country, len(iban))
+	}
+
+	// move the country and check digits last, and convert letters to numbers
+	var digits strings.Builder
+	for _, c := range iban[4:] + iban[:4] {
+		if c >= 'A' && c <= 'Z' {
+			digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+			continue
+		}
+		digits.WriteRune(c)
+	}
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	if n.Mod(n, big.NewInt(97)).Int64() != 1 {
+		return NewError(CodeIBANInvalidChecksum, str, nil, "invalid IBAN check digits")
+	}
+	return nil
+}
+
+// BIC checks that the value is a BIC, also known as SWIFT code,
+// of 8 or 11 characters, such as DEUTDEFF or DEUTDEFF500
+func BIC(_ string, value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return NewError(CodeBICInvalidType, value, nil, "expected a string, but got %T", value)
+	}
+	if !bicRegExp.MatchString(str) {
+		return NewError(CodeBICInvalidFormat, str, nil, "expected a BIC of 8 or 11 characters, such as DEUTDEFF")
+	}
+	return nil
+}
+
+// Currency checks that the value is an active ISO 4217 currency code, such as EUR
+func Currency(_ string, value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return NewError(CodeCurrencyInvalidType, value, nil, "expected a string, but got %T", value)
+	}
+	if _, ok := currencies[str]; !ok {
+		return NewError(CodeCurrencyUnknown, str, nil, "expected an ISO 4217 currency code, but got: %s", str)
+	}
+	return nil
+}
+
+// MinorUnits returns the number of decimals amounts in the given currency
+// have, such as 2 for EUR or 0 for JPY. It is -1 for codes without minor
+// units, such as XAU.
+func MinorUnits(currency string) (units int, ok bool) {
+	units, ok = currencies[currency]
+	return
+}
+
+// Money checks that the value is an amount with no more decimals than the
+// minor units of the currency given as argument: `money:EUR` accepts 12.5
+// but not 12.345. Amounts are strings, json.Numbers, integers or floats.
+func Money(args string, value interface{}) error {
+	units, ok := currencies[args]
+	if !ok {
+		return NewError(CodeMoneyInvalidArgs, value, Params{"currency": args}, "money requires an ISO 4217 currency code as a parameter, got: %s", args)
+	}
+
+	var amount string
+	switch v := value.(type) {
+	case string:
+		amount = v
+	case []byte:
+		amount = string(v)
+	case json.Number:
+		amount = v.String()
+	case float32:
+		amount = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		amount = strconv.FormatFloat(v, 'f', -1, 64)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return nil
+	default:
+		return NewError(CodeMoneyInvalidType, value, Params{"currency": args}, "expected an amount, but got %T", value)
+	}
+
+	if !amountRegExp.MatchString(amount) {
+		return NewError(CodeMoneyInvalidFormat, value, Params{"currency": args}, "expected an amount, such as 12.50, but got: %s", amount)
+	}
+	var decimals int
+	if i := strings.IndexByte(amount, '.'); i >= 0 {
+		decimals = len(amount) - i - 1
+	}
+	if units >= 0 && decimals > units {
+		return NewError(CodeMoneyTooManyDecimals, value, Params{"currency": args, "max": units, "decimals": decimals}, "expected at most %d decimals for %s, but got %d", units, args, decimals)
+	}
+	return nil
+}
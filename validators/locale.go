@@ -0,0 +1,98 @@
+package validators
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Timezone checks that the value is the name of an IANA timezone, such as
+// Europe/Paris or UTC, as loaded by time.LoadLocation from the timezone
+// database of the system. Programs running without one, such as in scratch
+// containers, may embed it by importing the validators/tzdata package.
+func Timezone(_ string, value interface{}) error {
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeTimezoneInvalidType, value, nil, "expected a string or []byte, but got %T", value)
+	}
+	// Local is the timezone of the system, rather than an IANA name
+	if str == "" || str == "Local" {
+		return NewError(CodeTimezoneUnknown, value, nil, "expected an IANA timezone, such as Europe/Paris, but got: %s", str)
+	}
+	if _, err := time.LoadLocation(str); err != nil {
+		return NewError(CodeTimezoneUnknown, value, nil, "expected an IANA timezone, such as Europe/Paris, but got: %s", str)
+	}
+	return nil
+}
+
+// BCP47 checks that the value is a BCP 47 language tag made of known
+// subtags, such as en, fr-CA or zh-Hant-TW. Subtags are separated by
+// hyphens, and case insensitive.
+func BCP47(_ string, value interface{}) error {
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeBCP47InvalidType, value, nil, "expected a string or []byte, but got %T", value)
+	}
+	if strings.Contains(str, "_") {
+		return NewError(CodeBCP47Invalid, value, nil, "expected a language tag with subtags separated by hyphens, such as en-US, but got: %s", str)
+	}
+	if _, err := language.Parse(str); err != nil {
+		return NewError(CodeBCP47Invalid, value, nil, "expected a language tag, such as en-US: %v", err)
+	}
+	return nil
+}
+
+// Country checks that the value is an ISO 3166-1 country code, in upper case:
+// an alpha-2 code such as FR by default or with `country:alpha2`, or an
+// alpha-3 code such as FRA with `country:alpha3`.
+func Country(args string, value interface{}) error {
+	if args != "" && args != "alpha2" && args != "alpha3" {
+		return NewError(CodeCountryInvalidArgs, value, Params{"format": args}, "country accepts alpha2 or alpha3 as a parameter, got: %s", args)
+	}
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeCountryInvalidType, value, nil, "expected a string or []byte, but got %T", value)
+	}
+
+	if args == "alpha3" {
+		for _, alpha3 := range countries {
+			if alpha3 == str {
+				return nil
+			}
+		}
+		return NewError(CodeCountryUnknown, value, Params{"format": "alpha3"}, "expected an ISO 3166-1 alpha-3 country code, such as FRA, but got: %s", str)
+	}
+	if _, ok := countries[str]; !ok {
+		return NewError(CodeCountryUnknown, value, Params{"format": "alpha2"}, "expected an ISO 3166-1 alpha-2 country code, such as FR, but got: %s", str)
+	}
+	return nil
+}
+
+// Subdivision checks that the value is an ISO 3166-2 subdivision code, in
+// upper case, such as US-CA or FR-IDF. The countries accepted may be given
+// as arguments, such as `subdivision:US|CA`.
+func Subdivision(args string, value interface{}) error {
+	var allowed []string
+	if args != "" {
+		allowed = strings.Split(args, "|")
+		for _, country := range allowed {
+			if _, ok := countries[country]; !ok {
+				return NewError(CodeSubdivisionInvalidArgs, value, Params{"countries": allowed}, "subdivision requires ISO 3166-1 alpha-2 country codes as parameters, got: %s", country)
+			}
+		}
+	}
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeSubdivisionInvalidType, value, nil, "expected a string or []byte, but got %T", value)
+	}
+
+	country, code, _ := strings.Cut(str, "-")
+	if code == "" || !strIn(code, strings.Fields(subdivisions[country])) {
+		return NewError(CodeSubdivisionUnknown, value, nil, "expected an ISO 3166-2 subdivision code, such as US-CA, but got: %s", str)
+	}
+	if allowed != nil && !strIn(country, allowed) {
+		return NewError(CodeSubdivisionCountryNotAllowed, value, Params{"country": country, "countries": allowed}, "accepted countries are: [%s], but got: %s", strings.Join(allowed, ", "), country)
+	}
+	return nil
+}
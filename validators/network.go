@@ -0,0 +1,268 @@
+package validators
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// reserved lists the ranges of addresses which are neither private
+// nor reachable on the internet, besides those netip reports.
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // this network
+	netip.MustParsePrefix("100.64.0.0/10"),   // shared address space
+	netip.MustParsePrefix("192.0.0.0/24"),    // protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local translation
+	netip.MustParsePrefix("100::/64"),        // discard
+}
+
+// IPAddr checks that the value is an IPv4 or IPv6 address
+func IPAddr(_ string, value interface{}) error {
+	_, err := parseAddr(value, CodeIPInvalid, CodeIPInvalidType)
+	return err
+}
+
+// IPv4 checks that the value is an IPv4 address.
+// IPv4-mapped IPv6 addresses, such as ::ffff:10.0.0.1, are IPv6 addresses.
+func IPv4(_ string, value interface{}) error {
+	addr, err := parseAddr(value, CodeIPv4Invalid, CodeIPv4InvalidType)
+	if err != nil {
+		return err
+	}
+	if !addr.Is4() {
+		return NewError(CodeIPv4Invalid, value, nil, "expected an IPv4 address, but got: %s", addr)
+	}
+	return nil
+}
+
+// IPv6 checks that the value is an IPv6 address
+func IPv6(_ string, value interface{}) error {
+	addr, err := parseAddr(value, CodeIPv6Invalid, CodeIPv6InvalidType)
+	if err != nil {
+		return err
+	}
+	if !addr.Is6() {
+		return NewError(CodeIPv6Invalid, value, nil, "expected an IPv6 address, but got: %s", addr)
+	}
+	return nil
+}
+
+// CIDR checks that the value is an IP prefix in CIDR notation, such as 10.0.0.0/8
+func CIDR(_ string, value interface{}) error {
+	if _, ok := value.(netip.Prefix); ok {
+		return nil
+	}
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeCIDRInvalidType, value, nil, "expected a string, []byte or netip.Prefix, but got %T", value)
+	}
+	if _, err := netip.ParsePrefix(str); err != nil {
+		return NewError(CodeCIDRInvalid, value, nil, "expected a CIDR prefix, such as 10.0.0.0/8: %v", err)
+	}
+	return nil
+}
+
+// IPIn checks that the value is an IP address within one of the
+// prefixes given as arguments, such as `ip_in:10.0.0.0/8|fd00::/8`
+func IPIn(args string, value interface{}) error {
+	var prefixes []netip.Prefix
+	for _, arg := range strings.Split(args, "|") {
+		prefix, err := netip.ParsePrefix(arg)
+		if err != nil {
+			return NewError(CodeIPInInvalidArgs, value, Params{"prefixes": strings.Split(args, "|")}, "ip_in requires CIDR prefixes as parameters: %v", err)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	addr, err := parseAddr(value, CodeIPInInvalid, CodeIPInInvalidType)
+	if err != nil {
+		return err
+	}
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) || prefix.Contains(addr.Unmap()) {
+			return nil
+		}
+	}
+	return NewError(CodeIPInNotInRange, value, Params{"prefixes": strings.Split(args, "|")}, "expected an address within %s, but got: %s", strings.Replace(args, "|", ", ", -1), addr)
+}
+
+// PublicIP checks that the value is an IP address reachable on the
+// internet: neither private, loopback, link-local, multicast, nor reserved
+// for documentation or other special purposes.
+func PublicIP(_ string, value interface{}) error {
+	addr, err := parseAddr(value, CodePublicIPInvalid, CodePublicIPInvalidType)
+	if err != nil {
+		return err
+	}
+	// mapped addresses reach their IPv4 counterpart
+	if unmapped := addr.Unmap(); !unmapped.IsGlobalUnicast() || unmapped.IsPrivate() || isReserved(unmapped) {
+		return NewError(CodePublicIPNotPublic, value, nil, "expected a public address, but got: %s", addr)
+	}
+	return nil
+}
+
+// PrivateIP checks that the value is a private IP address,
+// as defined by RFC 1918 and RFC 4193
+func PrivateIP(_ string, value interface{}) error {
+	addr, err := parseAddr(value, CodePrivateIPInvalid, CodePrivateIPInvalidType)
+	if err != nil {
+		return err
+	}
+	if !addr.Unmap().IsPrivate() {
+		return NewError(CodePrivateIPNotPrivate, value, nil, "expected a private address, but got: %s", addr)
+	}
+	return nil
+}
+
+// MAC checks that the value is a MAC address, such as 00:00:5e:00:53:01
+func MAC(_ string, value interface{}) error {
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeMACInvalidType, value, nil, "expected a string or []byte, but got %T", value)
+	}
+	if _, err := net.ParseMAC(str); err != nil {
+		return NewError(CodeMACInvalid, value, nil, "expected a MAC address, such as 00:00:5e:00:53:01")
+	}
+	return nil
+}
+
+// Port checks that the value is a port number, from 1 to 65535.
+// Strings and []byte made of ASCII digits, and integers, are accepted.
+func Port(_ string, value interface{}) error {
+	var port int64
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		port = rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() <= 65535 {
+			port = int64(rv.Uint())
+		}
+	default:
+		str, ok := text(value)
+		if !ok {
+			return NewError(CodePortInvalidType, value, nil, "expected a string, []byte or integer, but got %T", value)
+		}
+		// unparsable ports, signed ones included, are left at zero, out of range
+		if digits, ok := stripDigits(str, ""); ok {
+			port, _ = strconv.ParseInt(digits, 10, 64)
+		}
+	}
+	if port < 1 || port > 65535 {
+		return NewError(CodePortInvalid, value, nil, "expected a port from 1 to 65535, but got: %v", value)
+	}
+	return nil
+}
+
+// HostPort checks that the value is a host and port, such as example.com:443
+// or [::1]:8080, the host being an IP address or a host name
+func HostPort(_ string, value interface{}) error {
+	if addrPort, ok := value.(netip.AddrPort); ok {
+		if !addrPort.IsValid() || addrPort.Port() == 0 {
+			return NewError(CodeHostPortInvalid, value, nil, "expected a host and port, such as example.com:443, but got: %s", addrPort)
+		}
+		return nil
+	}
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeHostPortInvalidType, value, nil, "expected a string, []byte or netip.AddrPort, but got %T", value)
+	}
+	host, port, err := net.SplitHostPort(str)
+	if err == nil && Port("", port) != nil {
+		err = strconv.ErrRange
+	}
+	if err == nil {
+		if _, addrErr := netip.ParseAddr(host); addrErr != nil && !isHostname(host, 1) {
+			err = strconv.ErrSyntax
+		}
+	}
+	if err != nil {
+		return NewError(CodeHostPortInvalid, value, nil, "expected a host and port, such as example.com:443, but got: %s", str)
+	}
+	return nil
+}
+
+// FQDN checks that the value is a fully qualified domain name, such as
+// www.example.com: dot separated labels of letters, digits and hyphens,
+// without a trailing dot.
+func FQDN(_ string, value interface{}) error {
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeFQDNInvalidType, value, nil, "expected a string or []byte, but got %T", value)
+	}
+	if !isHostname(str, 2) {
+		return NewError(CodeFQDNInvalid, value, nil, "expected a fully qualified domain name, such as www.example.com, but got: %s", str)
+	}
+	return nil
+}
+
+// isHostname reports whether name is made of at least min valid labels,
+// the last of which is not numeric
+func isHostname(name string, min int) bool {
+	if len(name) == 0 || len(name) > 253 {
+		return false
+	}
+	labels := strings.Split(name, ".")
+	if len(labels) < min {
+		return false
+	}
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	_, err := strconv.Atoi(labels[len(labels)-1])
+	return err != nil
+}
+
+// parseAddr parses value into an IP address, reporting failures with the given codes
+func parseAddr(value interface{}, invalid, invalidType string) (netip.Addr, error) {
+	if addr, ok := value.(netip.Addr); ok {
+		if !addr.IsValid() {
+			return addr, NewError(invalid, value, nil, "expected an IP address, but got the zero netip.Addr")
+		}
+		return addr, nil
+	}
+	str, ok := text(value)
+	if !ok {
+		return netip.Addr{}, NewError(invalidType, value, nil, "expected a string, []byte or netip.Addr, but got %T", value)
+	}
+	addr, err := netip.ParseAddr(str)
+	if err != nil {
+		return addr, NewError(invalid, value, nil, "expected an IP address, but got: %s", str)
+	}
+	return addr, nil
+}
+
+func isReserved(addr netip.Addr) bool {
+	for _, prefix := range reserved {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// text returns value as a string, if a string or []byte
+func text(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,139 @@
+package validators
+
+import (
+	"regexp"
+	"strings"
+)
+
+var e164RegExp = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// E164 checks that the value is a phone number in the E.164 format:
+// a + followed by 7 to 15 digits, without separators, such as +33123456789
+func E164(_ string, value interface{}) error {
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeE164InvalidType, value, nil, "expected a string or []byte, but got %T", value)
+	}
+	if !e164RegExp.MatchString(str) {
+		return NewError(CodeE164Invalid, value, nil, "expected a phone number in the E.164 format, such as +33123456789, but got: %s", str)
+	}
+	return nil
+}
+
+// Phone checks that the value is a phone number of one of the countries
+// given as arguments, such as `phone:FR` or `phone:US|CA`, matching the
+// length and leading digits of their numbering plans. Numbers may be written
+// in their national format, such as 01 23 45 67 89, or in their international
+// format, starting with + or 00. Without arguments, numbers must be written
+// in their international format, and are checked against the numbering plan
+// of their country calling code, when known.
+func Phone(args string, value interface{}) error {
+	var countries []string
+	if args != "" {
+		countries = strings.Split(args, "|")
+		for _, country := range countries {
+			if _, ok := phonePlans[country]; !ok {
+				return NewError(CodePhoneInvalidArgs, value, Params{"countries": countries}, "phone requires the ISO 3166-1 alpha-2 codes of supported countries as parameters, got: %s", country)
+			}
+		}
+	}
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodePhoneInvalidType, value, nil, "expected a string or []byte, but got %T", value)
+	}
+	if _, code := normalizePhone(str, countries); code != "" {
+		if code == CodePhoneCountryNotAllowed {
+			return NewError(code, value, Params{"countries": countries}, "expected a phone number of %s, but got: %s", strings.Join(countries, ", "), str)
+		}
+		if countries == nil {
+			return NewError(code, value, nil, "expected a phone number in the international format, such as +33 1 23 45 67 89, but got: %s", str)
+		}
+		return NewError(code, value, Params{"countries": countries}, "expected a phone number of %s, but got: %s", strings.Join(countries, ", "), str)
+	}
+	return nil
+}
+
+// NormalizePhone returns number in the E.164 format, such as +33123456789,
+// and whether it is a valid phone number. National numbers are read as
+// numbers of the given countries, by ISO 3166-1 alpha-2 code. See Phone.
+func NormalizePhone(number string, countries ...string) (string, bool) {
+	for _, country := range countries {
+		if _, ok := phonePlans[country]; !ok {
+			return "", false
+		}
+	}
+	e164, code := normalizePhone(number, countries)
+	return e164, code == ""
+}
+
+// normalizePhone returns number in the E.164 format, or the code of the
+// error it is invalid with.
+func normalizePhone(number string, countries []string) (e164, code string) {
+	digits, international, ok := phoneDigits(number)
+	if !ok {
+		return "", CodePhoneInvalid
+	}
+
+	if !international {
+		for _, country := range countries {
+			plan := phonePlans[country]
+			if nsn, ok := plan.national(digits); ok {
+				return "+" + plan.code + nsn, ""
+			}
+		}
+		return "", CodePhoneInvalid
+	}
+
+	// country calling codes are 1 to 3 digits long, and none is the prefix of another
+	var known bool
+	for n := 1; n <= 3 && n < len(digits); n++ {
+		for _, country := range phoneCodes[digits[:n]] {
+			if countries != nil && !strIn(country, countries) {
+				continue
+			}
+			known = true
+			plan := phonePlans[country]
+			// the trunk prefix is sometimes kept, as in +44 (0)20 7946 0000
+			if nsn, ok := plan.national(digits[n:]); ok {
+				return "+" + plan.code + nsn, ""
+			}
+		}
+	}
+	switch {
+	case known:
+		return "", CodePhoneInvalid
+	case countries != nil:
+		return "", CodePhoneCountryNotAllowed
+	case !e164RegExp.MatchString("+" + digits):
+		return "", CodePhoneInvalid
+	}
+	return "+" + digits, ""
+}
+
+// national returns the national significant number of digits, a national
+// number with or without its trunk prefix, if it fits the plan
+func (plan phonePlan) national(digits string) (string, bool) {
+	if plan.trunk != "" && strings.HasPrefix(digits, plan.trunk) && plan.fits(digits[len(plan.trunk):]) {
+		return digits[len(plan.trunk):], true
+	}
+	return digits, plan.fits(digits)
+}
+
+// fits reports whether nsn is a national significant number of the plan
+func (plan phonePlan) fits(nsn string) bool {
+	return len(nsn) >= plan.lengths[0] && len(nsn) <= plan.lengths[1] && strings.IndexByte(plan.leading, nsn[0]) >= 0
+}
+
+// phoneDigits returns the digits of a phone number, dropping the separators
+// in between, and whether it is written in the international format.
+func phoneDigits(number string) (digits string, international, ok bool) {
+	number = strings.TrimSpace(number)
+	if strings.HasPrefix(number, "+") {
+		number, international = number[1:], true
+	}
+	digits, ok = stripDigits(number, " .-()/")
+	if ok && !international && strings.HasPrefix(digits, "00") {
+		digits, international = digits[2:], true
+	}
+	return digits, international, ok && digits != ""
+}
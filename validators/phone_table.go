@@ -0,0 +1,72 @@
+package validators
+
+import "sort"
+
+// phonePlan describes the numbering plan of a country, coarsely: enough
+// to catch mistyped numbers, not to tell whether a number is allocated.
+type phonePlan struct {
+	// code is the country calling code
+	code string
+	// trunk is the prefix dialed before national numbers, if any
+	trunk string
+	// lengths bounds the length of national significant numbers
+	lengths [2]int
+	// leading lists the first digits national significant numbers may start with
+	leading string
+}
+
+// phonePlans holds the numbering plans of each country, by ISO 3166-1 alpha-2 code,
+// as published by the ITU-T in its national numbering plans.
+var phonePlans = map[string]phonePlan{
+	"AE": {"971", "0", [2]int{8, 9}, "234679"},
+	"AR": {"54", "0", [2]int{10, 11}, "1239"},
+	"AT": {"43", "0", [2]int{4, 13}, "123456789"},
+	"AU": {"61", "0", [2]int{9, 9}, "23478"},
+	"BE": {"32", "0", [2]int{8, 9}, "123456789"},
+	"BR": {"55", "0", [2]int{10, 11}, "123456789"},
+	"CA": {"1", "1", [2]int{10, 10}, "23456789"},
+	"CH": {"41", "0", [2]int{9, 9}, "23456789"},
+	"CN": {"86", "0", [2]int{10, 11}, "123456789"},
+	"CZ": {"420", "", [2]int{9, 9}, "23456789"},
+	"DE": {"49", "0", [2]int{6, 13}, "123456789"},
+	"DK": {"45", "", [2]int{8, 8}, "23456789"},
+	"ES": {"34", "", [2]int{9, 9}, "6789"},
+	"FI": {"358", "0", [2]int{5, 12}, "123456789"},
+	"FR": {"33", "0", [2]int{9, 9}, "123456789"},
+	"GB": {"44", "0", [2]int{9, 10}, "1235789"},
+	"GR": {"30", "", [2]int{10, 10}, "2678"},
+	"HK": {"852", "", [2]int{8, 8}, "2356789"},
+	"IE": {"353", "0", [2]int{7, 9}, "123456789"},
+	"IL": {"972", "0", [2]int{8, 9}, "2345789"},
+	"IN": {"91", "0", [2]int{10, 10}, "123456789"},
+	"IT": {"39", "", [2]int{6, 11}, "038"},
+	"JP": {"81", "0", [2]int{9, 10}, "123456789"},
+	"KR": {"82", "0", [2]int{8, 10}, "1234567"},
+	"KZ": {"7", "8", [2]int{10, 10}, "67"},
+	"MX": {"52", "", [2]int{10, 10}, "123456789"},
+	"NG": {"234", "0", [2]int{8, 10}, "123456789"},
+	"NL": {"31", "0", [2]int{9, 9}, "123456789"},
+	"NO": {"47", "", [2]int{8, 8}, "23456789"},
+	"NZ": {"64", "0", [2]int{8, 10}, "23456789"},
+	"PL": {"48", "", [2]int{9, 9}, "123456789"},
+	"PT": {"351", "", [2]int{9, 9}, "29"},
+	"RU": {"7", "8", [2]int{10, 10}, "3489"},
+	"SE": {"46", "0", [2]int{7, 10}, "123456789"},
+	"SG": {"65", "", [2]int{8, 8}, "3689"},
+	"TR": {"90", "0", [2]int{10, 10}, "23458"},
+	"US": {"1", "1", [2]int{10, 10}, "23456789"},
+	"ZA": {"27", "0", [2]int{9, 9}, "12345678"},
+}
+
+// phoneCodes lists the countries sharing each country calling code, such as
+// KZ and RU for 7, in alphabetical order: their plans are tried in that order.
+var phoneCodes = func() map[string][]string {
+	codes := make(map[string][]string)
+	for country, plan := range phonePlans {
+		codes[plan.code] = append(codes[plan.code], country)
+	}
+	for _, countries := range codes {
+		sort.Strings(countries)
+	}
+	return codes
+}()
@@ -1,6 +1,11 @@
 package validators
 
-import "regexp"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
 
 // Code in this file is taken from asaskevich/govalidator
 
@@ -202,3 +207,34 @@ var regexMap = map[string]*regexp.Regexp{
 	"has_lowercase":   HasLowerCaseRegExp,
 	"has_uppercase":   HasUpperCaseRegExp,
 }
+
+// regexMu guards regexMap, which RegisterPattern extends
+var regexMu sync.RWMutex
+
+// Pattern returns the compiled RegExp registered for the given matcher name
+func Pattern(name string) (exp *regexp.Regexp, ok bool) {
+	regexMu.RLock()
+	defer regexMu.RUnlock()
+	exp, ok = regexMap[name]
+	return
+}
+
+// RegisterPattern compiles pattern, registering it under name for the matches
+// validator. Names may neither be registered twice, replace built-in patterns,
+// nor hold characters of the tag grammar, such as ",", "|" or ":".
+func RegisterPattern(name, pattern string) error {
+	if name == "" || strings.ContainsAny(name, ":,()!|;'\\ \t") {
+		return fmt.Errorf("invalid matcher name %q", name)
+	}
+	exp, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern for matcher %s: %v", name, err)
+	}
+	regexMu.Lock()
+	defer regexMu.Unlock()
+	if _, ok := regexMap[name]; ok {
+		return fmt.Errorf("matcher %s is already registered", name)
+	}
+	regexMap[name] = exp
+	return nil
+}
@@ -0,0 +1,209 @@
+package validators
+
+import (
+	"strings"
+	"time"
+)
+
+// dateLayout is the layout of dates without a time, in UTC
+const dateLayout = "2006-01-02"
+
+// Clock returns the current time, which relative bounds such as `after:now` are resolved against
+type Clock func() time.Time
+
+// ClockFuncMap holds the rules reading the current time, given the clock to read.
+// Their FuncMap counterparts read time.Now.
+var ClockFuncMap = map[string]func(now Clock, args string, value interface{}) error{
+	"before": func(now Clock, args string, value interface{}) error {
+		return compareTime(now, "before", args, value)
+	},
+	"after": func(now Clock, args string, value interface{}) error {
+		return compareTime(now, "after", args, value)
+	},
+}
+
+// Datetime checks that the value is a string matching the time layout given
+// as argument, such as `datetime:2006-01-02` or `datetime:15:04`. See time.Parse.
+// Values of type time.Time and *time.Time are times already, and are accepted
+// unless nil.
+func Datetime(args string, value interface{}) error {
+	if args == "" {
+		return NewError(CodeDatetimeInvalidArgs, value, Params{"layout": args}, "datetime requires a time layout as a parameter, such as 2006-01-02")
+	}
+	switch v := value.(type) {
+	case time.Time:
+		return nil
+	case *time.Time:
+		if v == nil {
+			return NewError(CodeDatetimeInvalid, value, Params{"layout": args}, "expected a time formatted as %s, but got nil", args)
+		}
+		return nil
+	}
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeDatetimeInvalidType, value, nil, "expected a time.Time or string, but got %T", value)
+	}
+	if _, err := time.Parse(args, str); err != nil {
+		return NewError(CodeDatetimeInvalid, value, Params{"layout": args}, "expected a time formatted as %s, but got: %s", args, str)
+	}
+	return nil
+}
+
+// RFC3339 checks that the value is a string holding an RFC 3339 timestamp,
+// such as 2006-01-02T15:04:05Z07:00, with optional fractional seconds.
+// Values of type time.Time and *time.Time are accepted, unless nil.
+func RFC3339(_ string, value interface{}) error {
+	switch v := value.(type) {
+	case time.Time:
+		return nil
+	case *time.Time:
+		if v == nil {
+			return NewError(CodeRFC3339Invalid, value, nil, "expected an RFC 3339 timestamp, but got nil")
+		}
+		return nil
+	}
+	str, ok := text(value)
+	if !ok {
+		return NewError(CodeRFC3339InvalidType, value, nil, "expected a time.Time or string, but got %T", value)
+	}
+	if _, err := time.Parse(time.RFC3339, str); err != nil {
+		return NewError(CodeRFC3339Invalid, value, nil, "expected an RFC 3339 timestamp, such as 2006-01-02T15:04:05Z, but got: %s", str)
+	}
+	return nil
+}
+
+// Before checks that the value is a time before the bound given as argument:
+// an RFC 3339 timestamp, a date such as `before:2030-01-01`, or a time
+// relative to now, such as `before:now` or `before:now+720h`.
+// Values may be time.Time, *time.Time, or strings holding timestamps or dates.
+func Before(args string, value interface{}) error {
+	return compareTime(time.Now, "before", args, value)
+}
+
+// After checks that the value is a time after the bound given as argument,
+// such as `after:now-24h`. See Before.
+func After(args string, value interface{}) error {
+	return compareTime(time.Now, "after", args, value)
+}
+
+// timeCodes holds the error codes of before and after, in order: out of
+// bounds, invalid value, invalid arguments and invalid type
+var timeCodes = map[string][4]string{
+	"before": {CodeBeforeNotBefore, CodeBeforeInvalid, CodeBeforeInvalidArgs, CodeBeforeInvalidType},
+	"after":  {CodeAfterNotAfter, CodeAfterInvalid, CodeAfterInvalidArgs, CodeAfterInvalidType},
+}
+
+// compareTime checks that value is before or after the bound of args,
+// resolving relative bounds against now
+func compareTime(now Clock, rule, args string, value interface{}) error {
+	codes := timeCodes[rule]
+
+	bound, ok := parseBound(now, args)
+	if !ok {
+		return NewError(codes[2], value, Params{"bound": args}, "%s requires a timestamp, a date or a time relative to now as a parameter, such as now+24h, got: %s", rule, args)
+	}
+
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return NewError(codes[1], value, nil, "expected a time, but got nil")
+		}
+		t = *v
+	default:
+		str, ok := text(value)
+		if !ok {
+			return NewError(codes[3], value, nil, "expected a time.Time or string, but got %T", value)
+		}
+		if t, ok = parseTime(str); !ok {
+			return NewError(codes[1], value, nil, "expected an RFC 3339 timestamp or a date, such as 2006-01-02, but got: %s", str)
+		}
+	}
+
+	if rule == "before" && t.Before(bound) || rule == "after" && t.After(bound) {
+		return nil
+	}
+	params := Params{"bound": args, "time": bound.Format(time.RFC3339)}
+	if !strings.HasPrefix(args, "now") {
+		return NewError(codes[0], value, params, "expected a time %s %s, but got: %s", rule, args, t.Format(time.RFC3339))
+	}
+	return NewError(codes[0], value, params, "expected a time %s %s (%s), but got: %s", rule, args, bound.Format(time.RFC3339), t.Format(time.RFC3339))
+}
+
+// parseBound parses the bound of before and after
+func parseBound(now Clock, args string) (time.Time, bool) {
+	if !strings.HasPrefix(args, "now") {
+		return parseTime(args)
+	}
+	if args == "now" {
+		return now(), true
+	}
+	offset, err := time.ParseDuration(args[len("now"):])
+	if err != nil || !strings.ContainsAny(args[len("now"):len("now")+1], "+-") {
+		return time.Time{}, false
+	}
+	return now().Add(offset), true
+}
+
+// parseTime parses an RFC 3339 timestamp, or a date in UTC
+func parseTime(s string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	t, err := time.Parse(dateLayout, s)
+	return t, err == nil
+}
+
+// Duration checks that the value is a duration within the bounds given as
+// arguments, such as `duration:1s..1h`. Either bound may be a wildcard:
+// `duration:1s..*`. Values may be time.Duration, or strings parsed by
+// time.ParseDuration, such as 1m30s.
+func Duration(args string, value interface{}) error {
+	min, max, ok := durationBounds(args)
+	if !ok {
+		return NewError(CodeDurationInvalidArgs, value, Params{"bounds": args}, "duration requires a range of durations as a parameter, such as 1s..1h, got: %s", args)
+	}
+
+	var d time.Duration
+	switch v := value.(type) {
+	case time.Duration:
+		d = v
+	default:
+		str, ok := text(value)
+		if !ok {
+			return NewError(CodeDurationInvalidType, value, nil, "expected a time.Duration or string, but got %T", value)
+		}
+		var err error
+		if d, err = time.ParseDuration(str); err != nil {
+			return NewError(CodeDurationInvalid, value, nil, "expected a duration, such as 1m30s, but got: %s", str)
+		}
+	}
+
+	if min != nil && d < *min || max != nil && d > *max {
+		parts := strings.SplitN(args, "..", 2)
+		return NewError(CodeDurationOutOfRange, value, Params{"min": parts[0], "max": parts[1]}, "expected a duration between %s and %s, but got: %s", parts[0], parts[1], d)
+	}
+	return nil
+}
+
+// durationBounds parses the bounds of duration, leaving wildcards unset
+func durationBounds(args string) (min, max *time.Duration, ok bool) {
+	parts := strings.SplitN(args, "..", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, nil, false
+	}
+	bounds := make([]*time.Duration, 2)
+	for i, part := range parts {
+		if part == "*" {
+			continue
+		}
+		d, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, nil, false
+		}
+		bounds[i] = &d
+	}
+	return bounds[0], bounds[1], true
+}
@@ -0,0 +1,12 @@
+// Package tzdata embeds the timezone database in programs, for the timezone
+// rule to fall back on where the system has none, such as in scratch
+// containers. It adds about 450KB to binaries, and is imported for its side
+// effects only:
+//
+//	import _ "github.com/ladydascalie/v/validators/tzdata"
+package tzdata
+
+import (
+	// the timezone database, for systems without one
+	_ "time/tzdata"
+)
@@ -1,10 +1,10 @@
 package validators
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -49,21 +49,68 @@ var CustomFuncMap = &customFuncMap{validators: make(map[string]Validator)}
 // FuncMap defines where all the validator live.
 // This is also where you need to add any custom validator that you need.
 var FuncMap = map[string]func(args string, value interface{}) error{
-	"required":      Required,
-	"maxchar":       Maxchar,
-	"in":            In,
-	"between":       Between,
-	"bytes_between": BytesBetween,
-	"empty_string":  EmptyString,
-	"is_int64":      IsInt64,
-	"is_float64":    IsFloat64,
-	"matches":       Matches,
+	"required":        Required,
+	"forbidden":       Forbidden,
+	"maxchar":         Maxchar,
+	"in":              In,
+	"between":         Between,
+	"bytes_between":   BytesBetween,
+	"empty_string":    EmptyString,
+	"is_int64":        IsInt64,
+	"is_float64":      IsFloat64,
+	"matches":         Matches,
+	"regex":           Regex,
+	"credit_card":     CardNumber,
+	"isbn":            ISBN,
+	"gtin":            GTIN,
+	"issn":            ISSN,
+	"imei":            IMEI,
+	"iban":            IBAN,
+	"bic":             BIC,
+	"currency":        Currency,
+	"money":           Money,
+	"ip":              IPAddr,
+	"ipv4":            IPv4,
+	"ipv6":            IPv6,
+	"cidr":            CIDR,
+	"ip_in":           IPIn,
+	"public_ip":       PublicIP,
+	"private_ip":      PrivateIP,
+	"mac":             MAC,
+	"port":            Port,
+	"hostport":        HostPort,
+	"fqdn":            FQDN,
+	"url":             AbsoluteURL,
+	"url_host":        URLHost,
+	"url_no_userinfo": URLNoUserinfo,
+	"url_max":         URLMax,
+	"url_relative":    RelativeURL,
+	"email":           EmailAddress,
+	"datetime":        Datetime,
+	"rfc3339":         RFC3339,
+	"before":          Before,
+	"after":           After,
+	"duration":        Duration,
+	"timezone":        Timezone,
+	"bcp47":           BCP47,
+	"country":         Country,
+	"subdivision":     Subdivision,
+	"e164":            E164,
+	"phone":           Phone,
 }
 
 // Required checks that the nullable type is in not nil
 func Required(_ string, value interface{}) error {
 	if sanity.IsNullable(value) && reflect.ValueOf(value).IsNil() {
-		return fmt.Errorf("required, please provide a value")
+		return NewError(CodeRequired, value, nil, "required, please provide a value")
+	}
+	return nil
+}
+
+// Forbidden checks that the value is the zero value of its type
+func Forbidden(_ string, value interface{}) error {
+	if value != nil && !reflect.ValueOf(value).IsZero() {
+		return NewError(CodeForbiddenPresent, value, nil, "forbidden, please do not provide a value")
 	}
 	return nil
 }
@@ -72,19 +119,20 @@ func Required(_ string, value interface{}) error {
 // In works on strings, slices of strings (it will check each contained values), or numbers.
 func In(args string, value interface{}) error {
 	accepted := strings.Split(args, "|")
+	params := Params{"accepted": accepted}
 
 	switch {
 	case sanity.IsString(value):
 		nv := value.(string)
 		if !strIn(nv, accepted) {
-			return fmt.Errorf("accepted values are: [%s], but got: %s", strings.Join(accepted, ", "), nv)
+			return NewError(CodeInNotAllowed, nv, params, "accepted values are: [%s], but got: %s", strings.Join(accepted, ", "), nv)
 		}
 		return nil
 	case sanity.IsStringSlice(value):
 		nv := value.([]string)
 		for _, item := range nv {
 			if !strIn(item, accepted) {
-				return fmt.Errorf("accepted values are: [%s], but got: %s", strings.Join(accepted, ", "), nv)
+				return NewError(CodeInNotAllowed, item, params, "accepted values are: [%s], but got: %s", strings.Join(accepted, ", "), nv)
 			}
 		}
 		return nil
@@ -94,14 +142,14 @@ func In(args string, value interface{}) error {
 		nv, _ := convert.ToFloat64(value)
 		values, err := stringSliceToFloatSlice(accepted)
 		if err != nil {
-			return fmt.Errorf("in requires numeric parameters to check for numeric values: %v", err)
+			return NewError(CodeInInvalidArgs, value, params, "in requires numeric parameters to check for numeric values: %v", err)
 		}
 		if !floatIn(nv, values) {
-			return fmt.Errorf("accepted values are: [%s], but got: %s", strings.Join(accepted, ", "), f64(nv))
+			return NewError(CodeInNotAllowed, value, params, "accepted values are: [%s], but got: %s", strings.Join(accepted, ", "), f64(nv))
 		}
 		return nil
 	default:
-		return fmt.Errorf("can only operate on string, []string, or numbers, got: %T", value)
+		return NewError(CodeInInvalidType, value, params, "can only operate on string, []string, or numbers, got: %T", value)
 	}
 }
 
@@ -109,24 +157,24 @@ func In(args string, value interface{}) error {
 func Maxchar(args string, value interface{}) error {
 	max, err := strconv.Atoi(args)
 	if err != nil {
-		return fmt.Errorf("maxchar requires an integer as a parameter")
+		return NewError(CodeMaxcharInvalidArgs, value, Params{"max": args}, "maxchar requires an integer as a parameter")
 	}
 	switch v := value.(type) {
 	case string:
 		count := utf8.RuneCountInString(v)
 		if count > max {
-			return fmt.Errorf("expected maximum %d characters, got: %d", max, count)
+			return NewError(CodeMaxcharTooLong, v, Params{"max": max, "count": count}, "expected maximum %d characters, got: %d", max, count)
 		}
 	case []string:
 		var count int
 		for _, item := range v {
 			count = utf8.RuneCountInString(item)
 			if count > max {
-				return fmt.Errorf("items have an expected maximum %d characters, got: %d on value: %s", max, count, item)
+				return NewError(CodeMaxcharTooLong, item, Params{"max": max, "count": count}, "items have an expected maximum %d characters, got: %d on value: %s", max, count, item)
 			}
 		}
 	default:
-		return fmt.Errorf("expected value of type string, but got %T", v)
+		return NewError(CodeMaxcharInvalidType, v, Params{"max": max}, "expected value of type string, but got %T", v)
 	}
 	return nil
 }
@@ -135,15 +183,15 @@ func Maxchar(args string, value interface{}) error {
 func BytesBetween(args string, value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("expected a string, but got %T", value)
+		return NewError(CodeBytesBetweenInvalidType, value, nil, "expected a string, but got %T", value)
 	}
 	min, max, err := bounds(args)
 	if err != nil {
-		return err
+		return NewError(CodeBytesBetweenInvalidArgs, value, Params{"range": args}, "%v", err)
 	}
 	total := float64(len(str))
 	if total < min || total > max {
-		return fmt.Errorf("expected a value between %s and %s, but got %s", f64(min), f64(max), f64(total))
+		return NewError(CodeBytesBetweenOutOfRange, str, Params{"min": min, "max": max, "length": total}, "expected a value between %s and %s, but got %s", f64(min), f64(max), f64(total))
 	}
 	return nil
 }
@@ -155,15 +203,15 @@ func Between(args string, value interface{}) error {
 	}
 	min, max, err := bounds(args)
 	if err != nil {
-		return err
+		return NewError(CodeBetweenInvalidArgs, value, Params{"range": args}, "%v", err)
 	}
 	nv, err := convert.ToFloat64(value)
 	if err != nil {
-		return err
+		return NewError(CodeBetweenInvalidType, value, Params{"min": min, "max": max}, "%v", err)
 	}
 
 	if nv < min || nv > max {
-		return fmt.Errorf("expected a value between %s and %s, but got %s", f64(min), f64(max), f64(nv))
+		return NewError(CodeBetweenOutOfRange, value, Params{"min": min, "max": max}, "expected a value between %s and %s, but got %s", f64(min), f64(max), f64(nv))
 	}
 	return nil
 }
@@ -172,12 +220,12 @@ func Between(args string, value interface{}) error {
 func EmptyString(_ string, value interface{}) error {
 	str, ok := value.(string)
 	if !ok {
-		return fmt.Errorf("expected an empty string but got a %T", value)
+		return NewError(CodeEmptyStringInvalidType, value, nil, "expected an empty string but got a %T", value)
 	}
 	if len(str) == 0 {
 		return nil
 	}
-	return fmt.Errorf("expected an empty string but got a string of byte length: %d", len(str))
+	return NewError(CodeEmptyStringNotEmpty, str, Params{"length": len(str)}, "expected an empty string but got a string of byte length: %d", len(str))
 }
 
 // IsInt64 checks if a given string or byte slice can be converted to an int64
@@ -186,11 +234,11 @@ func IsInt64(_ string, value interface{}) error {
 	case string, []byte:
 		_, err := convert.ToInt64(value)
 		if err != nil {
-			return fmt.Errorf("expected a value that can be parsed into an int, but got a %T", value)
+			return NewError(CodeIsInt64Invalid, value, nil, "expected a value that can be parsed into an int, but got a %T", value)
 		}
 		return nil
 	default:
-		return errors.New("this validator can only be used on strings or byte slices")
+		return NewError(CodeIsInt64InvalidType, value, nil, "this validator can only be used on strings or byte slices")
 	}
 }
 
@@ -200,49 +248,98 @@ func IsFloat64(_ string, value interface{}) error {
 	case string, []byte:
 		_, err := convert.ToFloat64(value)
 		if err != nil {
-			return fmt.Errorf("expected a value that can be parsed into a float, but got a %T", value)
+			return NewError(CodeIsFloat64Invalid, value, nil, "expected a value that can be parsed into a float, but got a %T", value)
 		}
 		return nil
 	default:
-		return errors.New("this validator can only be used on strings or byte slices")
+		return NewError(CodeIsFloat64InvalidType, value, nil, "this validator can only be used on strings or byte slices")
+	}
+}
+
+// matchChecksums verifies the check digits of the values
+// matching the patterns which only describe their shape
+var matchChecksums = map[string]func(s string) error{
+	"credit_card": func(s string) error { return CardNumber("", s) },
+	"isbn10":      func(s string) error { return ISBN("10", s) },
+	"isbn13":      func(s string) error { return ISBN("13", s) },
+}
+
+// matchString reports whether s matches exp, the pattern named name,
+// with valid check digits for those of matchChecksums
+func matchString(exp *regexp.Regexp, name, s string) bool {
+	if !exp.MatchString(s) {
+		return false
 	}
+	check, ok := matchChecksums[name]
+	return !ok || check(s) == nil
 }
 
 // Matches checks against RegExp patterns to see if the
-// provided data matches the expected format.
+// provided data matches the expected format. The check digits
+// of credit_card, isbn10 and isbn13 are verified as well.
 func Matches(args string, value interface{}) error {
+	params := Params{"pattern": args}
+	exp, ok := Pattern(args)
 	switch v := value.(type) {
 	case string:
-		exp, ok := regexMap[args]
 		if !ok {
-			return fmt.Errorf("no regex found for matcher: %s", args)
+			return NewError(CodeMatchesUnknownPattern, v, params, "no regex found for matcher: %s", args)
 		}
-		if !exp.MatchString(v) {
-			return fmt.Errorf("cannot validate data as %s", args)
+		if !matchString(exp, args, v) {
+			return NewError(CodeMatchesNoMatch, v, params, "cannot validate data as %s", args)
 		}
 		return nil
 	case []byte:
-		exp, ok := regexMap[args]
 		if !ok {
-			return fmt.Errorf("no regex found for matcher: %s", args)
+			return NewError(CodeMatchesUnknownPattern, v, params, "no regex found for matcher: %s", args)
 		}
-		if !exp.Match(v) {
-			return fmt.Errorf("cannot validate data as %s", args)
+		if !matchString(exp, args, string(v)) {
+			return NewError(CodeMatchesNoMatch, v, params, "cannot validate data as %s", args)
 		}
 		return nil
 	case []string:
-		exp, ok := regexMap[args]
 		if !ok {
-			return fmt.Errorf("no regex found for matcher: %s", args)
+			return NewError(CodeMatchesUnknownPattern, v, params, "no regex found for matcher: %s", args)
+		}
+		for _, entry := range v {
+			if !matchString(exp, args, entry) {
+				return NewError(CodeMatchesNoMatch, entry, params, "cannot validate data as %s", args)
+			}
 		}
+		return nil
+	default:
+		return NewError(CodeMatchesInvalidType, v, params, "matches can only operate on strings, []byte, or []string")
+	}
+}
+
+// Regex checks the value against the regular expression given as argument,
+// such as `regex:'^[a-z]+(-[a-z]+)*$'`. Compiled expressions are cached.
+func Regex(args string, value interface{}) error {
+	params := Params{"pattern": args}
+	exp, err := compileRegex(args)
+	if err != nil {
+		return NewError(CodeRegexInvalidArgs, value, params, "invalid regular expression: %v", err)
+	}
+	switch v := value.(type) {
+	case string:
+		if !exp.MatchString(v) {
+			return NewError(CodeRegexNoMatch, v, params, "does not match %s", args)
+		}
+		return nil
+	case []byte:
+		if !exp.Match(v) {
+			return NewError(CodeRegexNoMatch, v, params, "does not match %s", args)
+		}
+		return nil
+	case []string:
 		for _, entry := range v {
 			if !exp.MatchString(entry) {
-				return fmt.Errorf("cannot validate data as %s", args)
+				return NewError(CodeRegexNoMatch, entry, params, "does not match %s", args)
 			}
 		}
 		return nil
 	default:
-		return errors.New("matches can only operate on strings, []byte, or []string")
+		return NewError(CodeRegexInvalidType, v, params, "regex can only operate on strings, []byte, or []string")
 	}
 }
 
@@ -284,11 +381,11 @@ func checkLength(args string, value interface{}) error {
 	str := value.(string) // already checked by between
 	min, max, err := bounds(args)
 	if err != nil {
-		return err
+		return NewError(CodeBetweenInvalidArgs, value, Params{"range": args}, "%v", err)
 	}
 	count := float64(utf8.RuneCountInString(str))
 	if count < min || count > max {
-		return fmt.Errorf("expected string length to be between %s and %s, but got %s", f64(min), f64(max), f64(count))
+		return NewError(CodeBetweenOutOfRange, str, Params{"min": min, "max": max, "length": count}, "expected string length to be between %s and %s, but got %s", f64(min), f64(max), f64(count))
 	}
 	return nil
 }
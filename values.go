@@ -0,0 +1,106 @@
+package v
+
+import (
+	"context"
+	"net/url"
+	"sort"
+
+	"github.com/ladydascalie/v/convert"
+)
+
+// Values validates url.Values, such as query strings or forms, against
+// rules keyed by parameter name, written as in the `v` tag:
+//
+//	v.Values(r.URL.Query(), map[string]string{
+//		"page": "is_int64,between:1..1000",
+//		"sort": "in:asc|desc",
+//	})
+//
+// Rules apply to each value of multi-valued keys. Missing keys are only
+// reported when required, and skip every other rule. Values are strings
+// until is_int64 or is_float64 succeed, which convert them so that the
+// rules after them, like between, operate on numbers. Empty values skip
+// every rule when omitempty.
+func Values(values url.Values, rules map[string]string) error {
+	return std.Values(values, rules)
+}
+
+// Values validates url.Values, see the package level Values.
+func (val *Validator) Values(values url.Values, rules map[string]string) error {
+	tr := val.translator(context.Background())
+
+	// sort the keys, so that errors are reported in a stable order
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var vErrors validationErorrs
+	for _, key := range keys {
+		vtags := parseRules(rules[key])
+		var omit, req bool
+		for _, vtag := range vtags {
+			omit = omit || vtag.has(omitempty)
+			req = req || vtag.has(required)
+		}
+
+		// a missing key is reported once, however many rules require it
+		if len(values[key]) == 0 {
+			if req {
+				vErrors = append(vErrors, ErrorRequired{
+					Field:      key,
+					Path:       key,
+					StructPath: key,
+					Locale:     tr.locale,
+					messages:   tr.messages,
+				})
+			}
+			continue
+		}
+
+		for _, raw := range values[key] {
+			if raw == "" && omit {
+				continue
+			}
+			var value interface{} = raw
+			for _, vtag := range vtags {
+				if vtag.is(required) || vtag.is(omitempty) {
+					continue
+				}
+				if err := vtag.run(value, values, val.clock); err != nil {
+					vErrors = append(vErrors, ErrorValidation{
+						Name:       key,
+						Path:       key,
+						StructPath: key,
+						Err:        err,
+						Locale:     tr.locale,
+						messages:   tr.messages,
+					})
+					// the rules after a failed conversion cannot be trusted
+					if vtag.is("is_int64") || vtag.is("is_float64") {
+						break
+					}
+					continue
+				}
+				value = coerce(vtag, value)
+			}
+		}
+	}
+	return vErrors.Error()
+}
+
+// coerce converts a value which passed is_int64 or is_float64
+func coerce(vtag *node, value interface{}) interface{} {
+	switch {
+	case vtag.is("is_int64"):
+		if i, err := convert.ToInt64(value); err == nil {
+			return i
+		}
+	case vtag.is("is_float64"):
+		if f, err := convert.ToFloat64(value); err == nil {
+			return f
+		}
+	}
+	return value
+}
@@ -0,0 +1,72 @@
+package v
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/ladydascalie/v/validators"
+)
+
+func TestValues(t *testing.T) {
+	rules := map[string]string{
+		"page": "required,is_int64,between:1..1000",
+		"sort": "in:asc|desc",
+		"tag":  "maxchar:5",
+	}
+	tests := []struct {
+		name      string
+		query     string
+		wantCodes []string
+	}{
+		{name: "valid", query: "page=10&sort=asc&tag=a&tag=b"},
+		{name: "optional keys may be missing", query: "page=1"},
+		{name: "missing required key", query: "sort=asc", wantCodes: []string{validators.CodeRequired}},
+		{name: "numeric rules apply to converted values", query: "page=1001", wantCodes: []string{validators.CodeBetweenOutOfRange}},
+		{name: "failed conversions stop there", query: "page=ten", wantCodes: []string{validators.CodeIsInt64Invalid}},
+		{name: "each value is validated", query: "page=1&tag=ok&tag=too_long&tag=way_too_long", wantCodes: []string{validators.CodeMaxcharTooLong, validators.CodeMaxcharTooLong}},
+		{name: "in", query: "page=1&sort=up", wantCodes: []string{validators.CodeInNotAllowed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = Values(values, rules)
+			if len(tt.wantCodes) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var errs Errors
+			if !errors.As(err, &errs) || len(errs) != len(tt.wantCodes) {
+				t.Fatalf("expected %d errors, got %v", len(tt.wantCodes), err)
+			}
+			for i, err := range errs {
+				var code string
+				switch e := err.(type) {
+				case ErrorRequired:
+					code = e.Code()
+				case ErrorValidation:
+					code = e.Code()
+				}
+				if code != tt.wantCodes[i] {
+					t.Errorf("errors[%d] code = %s, want %s", i, code, tt.wantCodes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValues_requiredOnce(t *testing.T) {
+	err := Values(url.Values{}, map[string]string{"q": "required,maxchar:5,(required || in:x)"})
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("expected a single error, got %v", err)
+	}
+	if _, ok := errs[0].(ErrorRequired); !ok {
+		t.Errorf("expected a required error, got %v", errs[0])
+	}
+}
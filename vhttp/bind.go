@@ -0,0 +1,177 @@
+// Package vhttp binds HTTP requests onto structs and validates them with v.
+//
+// It is named so as not to shadow net/http in the files importing it.
+package vhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/ladydascalie/v"
+	"github.com/ladydascalie/v/convert"
+)
+
+const (
+	querytag = "query"
+	formtag  = "form"
+)
+
+var (
+	fileHeaderType  = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// Binder decodes and validates requests
+type Binder struct {
+	// MaxBytes limits the size of request bodies
+	MaxBytes int64
+	// MaxMemory is how much of a multipart body is kept in memory,
+	// the rest of it being stored in temporary files.
+	MaxMemory int64
+	// Validator validates the decoded values
+	Validator *v.Validator
+}
+
+// DefaultBinder is the Binder used by Bind
+var DefaultBinder = &Binder{
+	MaxBytes:  1 << 20,
+	MaxMemory: 1 << 20,
+	Validator: v.New(),
+}
+
+// Error is returned when a request cannot be decoded
+type Error struct {
+	// Status is the HTTP status best describing the failure
+	Status int
+	Err    error
+}
+
+// Error satisfies the builtin Error interface
+func (e *Error) Error() string {
+	return fmt.Sprintf("vhttp: %v", e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Bind decodes the request into a T, using the DefaultBinder, then validates it.
+// See BindWith.
+func Bind[T any](r *http.Request) (T, error) {
+	return BindWith[T](DefaultBinder, r)
+}
+
+// BindWith decodes the request into a T, then validates it.
+//
+// Query parameters are decoded into the fields with a `query` tag. The body
+// is decoded according to its content type: JSON bodies with encoding/json,
+// url-encoded and multipart forms into the fields with a `form` tag.
+// Uploaded files are bound to *multipart.FileHeader and []*multipart.FileHeader fields.
+//
+// Decoding failures are reported as an *Error, validation failures as
+// returned by the binder's Validator.
+func BindWith[T any](b *Binder, r *http.Request) (T, error) {
+	var dst T
+	rv := reflect.ValueOf(&dst).Elem()
+	if rv.Kind() != reflect.Struct {
+		return dst, fmt.Errorf("vhttp: can only bind structs, got %T", dst)
+	}
+
+	if err := decodeValues(rv, querytag, r.URL.Query(), nil); err != nil {
+		return dst, &Error{Status: http.StatusBadRequest, Err: err}
+	}
+	if err := b.decodeBody(r, rv, &dst); err != nil {
+		return dst, err
+	}
+	return dst, b.Validator.StructContext(r.Context(), dst)
+}
+
+func (b *Binder) decodeBody(r *http.Request, rv reflect.Value, dst interface{}) error {
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		return nil
+	}
+	r.Body = http.MaxBytesReader(nil, r.Body, b.MaxBytes)
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return &Error{Status: http.StatusUnsupportedMediaType, Err: err}
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		err = json.NewDecoder(r.Body).Decode(dst)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	case mediaType == "application/x-www-form-urlencoded":
+		if err = r.ParseForm(); err == nil {
+			err = decodeValues(rv, formtag, r.PostForm, nil)
+		}
+	case mediaType == "multipart/form-data":
+		if err = r.ParseMultipartForm(b.MaxMemory); err == nil {
+			err = decodeValues(rv, formtag, r.MultipartForm.Value, r.MultipartForm.File)
+		}
+	default:
+		return &Error{Status: http.StatusUnsupportedMediaType, Err: fmt.Errorf("unsupported media type %s", mediaType)}
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return &Error{Status: http.StatusRequestEntityTooLarge, Err: err}
+	case err != nil:
+		return &Error{Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
+
+// decodeValues sets the fields of rv tagged with tag from values,
+// and from files when the fields are meant to hold uploads.
+func decodeValues(rv reflect.Value, tag string, values url.Values, files map[string][]*multipart.FileHeader) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := rv.Field(i)
+
+		// flatten embedded structs
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := decodeValues(value, tag, values, files); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := field.Tag.Get(tag)
+		if i := strings.Index(name, ","); i >= 0 {
+			name = name[:i]
+		}
+		if name == "" || name == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		switch field.Type {
+		case fileHeaderType:
+			if len(files[name]) > 0 {
+				value.Set(reflect.ValueOf(files[name][0]))
+			}
+			continue
+		case fileHeadersType:
+			value.Set(reflect.ValueOf(files[name]))
+			continue
+		}
+
+		if err := convert.Set(value, values[name]...); err != nil {
+			return fmt.Errorf("%s %s: %v", tag, name, err)
+		}
+	}
+	return nil
+}
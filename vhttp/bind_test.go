@@ -0,0 +1,146 @@
+package vhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ladydascalie/v"
+	"github.com/ladydascalie/v/problem"
+)
+
+type search struct {
+	Query string   `query:"q" v:"maxchar:10"`
+	Page  int      `query:"page" v:"between:1..100"`
+	Tags  []string `query:"tag"`
+}
+
+type signup struct {
+	Name   string                `json:"name" form:"name" v:"between:1..20"`
+	Age    int                   `json:"age" form:"age" v:"between:21..*"`
+	Avatar *multipart.FileHeader `form:"avatar"`
+}
+
+func TestBind_query(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/?q=gophers&page=2&tag=a&tag=b", nil)
+	got, err := Bind[search](r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Query != "gophers" || got.Page != 2 || strings.Join(got.Tags, ",") != "a,b" {
+		t.Errorf("unexpected binding: %+v", got)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?page=0", nil)
+	if _, err := Bind[search](r); !errors.As(err, new(v.Errors)) {
+		t.Errorf("expected validation errors, got %v", err)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?page=two", nil)
+	var berr *Error
+	if _, err := Bind[search](r); !errors.As(err, &berr) || berr.Status != http.StatusBadRequest {
+		t.Errorf("expected a decoding error, got %v", err)
+	}
+}
+
+func TestBind_body(t *testing.T) {
+	var multipartBody bytes.Buffer
+	mw := multipart.NewWriter(&multipartBody)
+	mw.WriteField("name", "Jane")
+	mw.WriteField("age", "30")
+	fw, _ := mw.CreateFormFile("avatar", "jane.png")
+	fw.Write([]byte("png"))
+	mw.Close()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantAvatar  bool
+	}{
+		{name: "json", contentType: "application/json", body: `{"name": "Jane", "age": 30}`},
+		{name: "json suffix", contentType: "application/vnd.api+json; charset=utf-8", body: `{"name": "Jane", "age": 30}`},
+		{name: "form", contentType: "application/x-www-form-urlencoded", body: url.Values{"name": {"Jane"}, "age": {"30"}}.Encode()},
+		{name: "multipart", contentType: mw.FormDataContentType(), body: multipartBody.String(), wantAvatar: true},
+		{name: "broken json", contentType: "application/json", body: `{"name":`, wantStatus: http.StatusBadRequest},
+		{name: "unsupported", contentType: "text/plain", body: `Jane`, wantStatus: http.StatusUnsupportedMediaType},
+		{name: "too large", contentType: "application/json", body: `{"name": "` + strings.Repeat("a", 2<<20) + `"}`, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+
+			got, err := Bind[signup](r)
+			if tt.wantStatus != 0 {
+				var berr *Error
+				if !errors.As(err, &berr) || berr.Status != tt.wantStatus {
+					t.Fatalf("expected an error with status %d, got %v", tt.wantStatus, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != "Jane" || got.Age != 30 {
+				t.Errorf("unexpected binding: %+v", got)
+			}
+			if tt.wantAvatar && (got.Avatar == nil || got.Avatar.Filename != "jane.png") {
+				t.Errorf("expected the avatar to be bound, got %+v", got.Avatar)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	handler := Middleware[signup](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s, ok := FromContext[signup](r.Context())
+		if !ok {
+			t.Error("expected the bound value in the context")
+		}
+		io.WriteString(w, s.Name)
+	}))
+
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "Jane", "age": 30}`))
+	r.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(rec, r)
+	if rec.Code != http.StatusOK || rec.Body.String() != "Jane" {
+		t.Errorf("got %d %s", rec.Code, rec.Body)
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{"invalid", "application/json", `{"name": "Jane", "age": 16}`, http.StatusUnprocessableEntity},
+		{"malformed", "application/json", `{"name": `, http.StatusBadRequest},
+		{"unsupported", "text/plain", `Jane`, http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			handler.ServeHTTP(rec, r)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var doc problem.Document
+			if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil || doc.Status != tt.wantStatus {
+				t.Errorf("expected a problem document, got %s", rec.Body)
+			}
+		})
+	}
+}
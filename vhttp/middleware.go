@@ -0,0 +1,42 @@
+package vhttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ladydascalie/v/problem"
+)
+
+type contextKey[T any] struct{}
+
+// Middleware binds every request into a T with the DefaultBinder, see Middleware.
+func Middleware[T any](next http.Handler) http.Handler {
+	return MiddlewareWith[T](DefaultBinder, next)
+}
+
+// MiddlewareWith binds every request into a T, handing it over to next
+// through the request context, where FromContext retrieves it.
+// Invalid requests are rejected with an application/problem+json document.
+func MiddlewareWith[T any](b *Binder, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dst, err := BindWith[T](b, r)
+		if err != nil {
+			doc := problem.New(err)
+			var berr *Error
+			if errors.As(err, &berr) {
+				doc.Status = berr.Status
+				doc.Title = http.StatusText(berr.Status)
+			}
+			doc.Write(w)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey[T]{}, dst)))
+	})
+}
+
+// FromContext returns the T bound by Middleware
+func FromContext[T any](ctx context.Context) (T, bool) {
+	dst, ok := ctx.Value(contextKey[T]{}).(T)
+	return dst, ok
+}